@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/aoc"
+	"github.com/spf13/cobra"
+)
+
+// addAOCFlags adds the flags for talking to the advent of code server, the session itself is only read from env or config.
+// They are bound to viper, so they must only be added once, to the root command
+func addAOCFlags(h *common.Helpers, cmd *cobra.Command) error {
+	flags := cmd.PersistentFlags()
+	flags.String(aoc.URLKey, aoc.DefaultURL, "base url of the advent of code server")
+	flags.String(aoc.SessionFileKey, "", "file holding the session cookie (or set "+common.EnvName(aoc.SessionKey)+")")
+	flags.Duration(aoc.ThrottleKey, aoc.DefaultThrottle, "minimum time between requests to the server")
+	for _, key := range []string{aoc.URLKey, aoc.SessionFileKey, aoc.ThrottleKey} {
+		err := h.Viper.BindPFlag(key, flags.Lookup(key))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/spf13/cobra"
+)
+
+// benchPhase is a struct that contains the measurements of one phase of a star
+type benchPhase struct {
+	Name      string
+	Durations []time.Duration
+	Mallocs   uint64
+	Bytes     uint64
+}
+
+// newBenchCmd creates a new bench command
+func newBenchCmd(h *common.Helpers, r *common.Registry) *cobra.Command {
+	var day, star, runs, warmup int
+	var verbose bool
+	benchCmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Benchmark a star",
+		Long:  "Run a star repeatedly and report parse and solve timings and allocations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := r.Get(day)
+			if err != nil {
+				return err
+			}
+			bh := h
+			if !verbose {
+				// logging and output from the solver would skew the timings
+				quiet := slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError}))
+				bh = h.WithStreams(common.NewStreams(h.Streams.In, io.Discard, io.Discard), quiet)
+			}
+			return bench(h, bh, s, common.Star(star), runs, warmup)
+		},
+	}
+	benchCmd.Flags().IntVar(&day, "day", 0, "day to benchmark")
+	benchCmd.Flags().IntVar(&star, "star", 1, "star to benchmark")
+	benchCmd.Flags().IntVar(&runs, "runs", 10, "number of measured runs")
+	benchCmd.Flags().IntVar(&warmup, "warmup", 1, "number of runs before measuring")
+	benchCmd.Flags().BoolVar(&verbose, "verbose", false, "keep the solver's logging and output")
+	_ = benchCmd.MarkFlagRequired("day")
+	return benchCmd
+}
+
+// bench runs a star repeatedly with the bench helpers, and reports with the original helpers
+func bench(h *common.Helpers, bh *common.Helpers, s common.Solver, star common.Star, runs int, warmup int) error {
+	if runs < 1 {
+		return fmt.Errorf("runs must be at least 1, got %d", runs)
+	}
+	f, err := common.GetInput(h, s)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < warmup; i++ {
+		_, err = benchRun(bh, s, star, f)
+		if err != nil {
+			return err
+		}
+	}
+
+	parse := &benchPhase{Name: common.ParsePhase}
+	solve := &benchPhase{Name: common.SolvePhase}
+	var answer int
+	var before, middle, after runtime.MemStats
+	for i := 0; i < runs; i++ {
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		in, err := s.Parse(bh, f)
+		parsed := time.Now()
+		if err != nil {
+			return err
+		}
+		runtime.ReadMemStats(&middle)
+		solveStart := time.Now()
+		answer, err = common.Solve(bh, s, star, in)
+		solved := time.Now()
+		if err != nil {
+			return err
+		}
+		runtime.ReadMemStats(&after)
+
+		parse.Durations = append(parse.Durations, parsed.Sub(start))
+		parse.Mallocs += middle.Mallocs - before.Mallocs
+		parse.Bytes += middle.TotalAlloc - before.TotalAlloc
+		solve.Durations = append(solve.Durations, solved.Sub(solveStart))
+		solve.Mallocs += after.Mallocs - middle.Mallocs
+		solve.Bytes += after.TotalAlloc - middle.TotalAlloc
+	}
+
+	info := s.Info()
+	_, err = fmt.Fprintf(h.Streams.Out, "%s %s: %d (%d runs, %d warm-up)\n", info.Human(), star.Human(), answer, runs, warmup)
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(h.Streams.Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PHASE\tMIN\tMEDIAN\tP95\tMAX\tALLOCS/OP\tBYTES/OP")
+	for _, p := range []*benchPhase{parse, solve} {
+		sorted := slices.Clone(p.Durations)
+		slices.Sort(sorted)
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%d\n",
+			p.Name,
+			sorted[0],
+			percentile(sorted, 50),
+			percentile(sorted, 95),
+			sorted[len(sorted)-1],
+			p.Mallocs/uint64(runs),
+			p.Bytes/uint64(runs),
+		)
+	}
+	return tw.Flush()
+}
+
+// benchRun parses and solves a star once
+func benchRun(h *common.Helpers, s common.Solver, star common.Star, f *common.File) (int, error) {
+	in, err := s.Parse(h, f)
+	if err != nil {
+		return 0, err
+	}
+	return common.Solve(h, s, star, in)
+}
+
+// percentile returns the nearest-rank percentile of sorted durations
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	// nearest rank is ceil(p/100 * n), as a 1-based index
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
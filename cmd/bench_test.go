@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPercentile is a test for the percentile function
+func TestPercentile(t *testing.T) {
+	ten := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	testCases := []struct {
+		name     string
+		input    []time.Duration
+		p        int
+		expected time.Duration
+	}{
+		{name: "empty", input: nil, p: 50, expected: 0},
+		{name: "single", input: []time.Duration{7}, p: 95, expected: 7},
+		{name: "median_even", input: ten, p: 50, expected: 5},
+		{name: "median_odd", input: []time.Duration{1, 2, 3}, p: 50, expected: 2},
+		{name: "p95", input: ten, p: 95, expected: 10},
+		{name: "p0", input: ten, p: 0, expected: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := percentile(tc.input, tc.p)
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
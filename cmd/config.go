@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/aoc"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// configKey is a struct that contains a setting that can be set in the config file
+type configKey struct {
+	Key    string
+	Secret bool
+}
+
+// configKeys returns every setting that can be set in the config file, including those of the days with flags
+func configKeys(r *common.Registry) []configKey {
+	keys := []configKey{
+		{Key: common.InputDirsKey},
+		{Key: common.CacheDirKey},
+		{Key: common.LedgerKey},
+		{Key: common.OutputKey},
+		{Key: common.LogLevelKey},
+		{Key: common.LogFormatKey},
+		{Key: common.LogFileKey},
+		{Key: WorkersKey},
+		{Key: aoc.URLKey},
+		{Key: aoc.SessionKey, Secret: true},
+		{Key: aoc.SessionFileKey},
+		{Key: aoc.ThrottleKey},
+	}
+	for _, s := range r.Solvers() {
+		if f, ok := s.(common.Flagger); ok {
+			for _, key := range f.ConfigKeys() {
+				keys = append(keys, configKey{Key: key})
+			}
+		}
+	}
+	return keys
+}
+
+// ErrInvalidConfig is an error that is returned when the config has problems
+type ErrInvalidConfig struct {
+	Count int
+}
+
+// Error returns the error message
+func (e ErrInvalidConfig) Error() string {
+	return fmt.Sprintf("config has %d problem(s)", e.Count)
+}
+
+// newConfigCmd creates a new config command
+func newConfigCmd(h *common.Helpers, r *common.Registry) *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the configuration",
+		Long:  "Inspect the configuration, settings come from flags, then env, then the config file, then defaults",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("No subcommand given")
+		},
+	}
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "Show the effective settings and where each came from",
+		Long:  "Show the effective settings and where each came from",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showConfig(h, r, cmd)
+		},
+	})
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "validate",
+		Short: "Check the configuration for problems",
+		Long:  "Check the configuration for unknown keys and bad values",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return validateConfig(h, r)
+		},
+	})
+
+	return configCmd
+}
+
+// configSource returns where the effective value of a key came from
+func configSource(cmd *cobra.Command, fileViper *viper.Viper, key string) string {
+	if f := cmd.Flags().Lookup(key); f != nil && f.Changed {
+		return "flag"
+	}
+	if _, ok := os.LookupEnv(common.EnvName(key)); ok {
+		return "env"
+	}
+	if fileViper.IsSet(key) {
+		return "file"
+	}
+	return "default"
+}
+
+// showConfig writes the effective settings and their sources
+func showConfig(h *common.Helpers, r *common.Registry, cmd *cobra.Command) error {
+	fileViper, path, err := common.ReadConfigFile(h.Viper)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		path = "none"
+	}
+	_, err = fmt.Fprintf(h.Streams.Out, "config file: %s\n", path)
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(h.Streams.Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tVALUE\tSOURCE\tENV")
+	for _, k := range configKeys(r) {
+		value := ""
+		if raw := h.Viper.Get(k.Key); raw != nil {
+			value = fmt.Sprint(raw)
+		}
+		if k.Secret && h.Viper.GetString(k.Key) != "" {
+			value = "********"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", k.Key, value, configSource(cmd, fileViper, k.Key), common.EnvName(k.Key))
+	}
+	return tw.Flush()
+}
+
+// validateConfig writes every problem with the configuration, and fails if there are any
+func validateConfig(h *common.Helpers, r *common.Registry) error {
+	fileViper, path, err := common.ReadConfigFile(h.Viper)
+	if err != nil {
+		return err
+	}
+	problems := make([]string, 0)
+	keys := configKeys(r)
+	known := make([]string, 0, len(keys))
+	for _, k := range keys {
+		known = append(known, k.Key)
+	}
+	for _, key := range fileViper.AllKeys() {
+		if !slices.Contains(known, key) {
+			problems = append(problems, fmt.Sprintf("unknown key in config file: %s", key))
+		}
+	}
+
+	v := h.Viper
+	if _, err := common.ParseLogLevel(v.GetString(common.LogLevelKey)); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if format := v.GetString(common.LogFormatKey); format != "" && !slices.Contains(common.LogFormats(), format) {
+		problems = append(problems, common.ErrUnknownLogFormat{Format: format}.Error())
+	}
+	if _, err := common.GetOutputFormat(h, common.TextOutput); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if workers := v.GetInt(WorkersKey); workers < 1 {
+		problems = append(problems, fmt.Sprintf("%s must be at least 1, got %d", WorkersKey, workers))
+	}
+	if file := v.GetString(common.LogFileKey); file != "" {
+		if info, err := os.Stat(filepath.Dir(file)); err != nil || !info.IsDir() {
+			problems = append(problems, fmt.Sprintf("%s isn't in a directory: %s", common.LogFileKey, file))
+		}
+	}
+	if throttle := v.GetDuration(aoc.ThrottleKey); throttle < 0 {
+		problems = append(problems, fmt.Sprintf("%s can't be negative, got %s", aoc.ThrottleKey, throttle))
+	}
+	if u, err := url.Parse(v.GetString(aoc.URLKey)); err != nil || (v.GetString(aoc.URLKey) != "" && u.Host == "") {
+		problems = append(problems, fmt.Sprintf("%s isn't a url: %q", aoc.URLKey, v.GetString(aoc.URLKey)))
+	}
+	for _, dir := range v.GetStringSlice(common.InputDirsKey) {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			problems = append(problems, fmt.Sprintf("%s entry isn't a directory: %s", common.InputDirsKey, dir))
+		}
+	}
+	if dir := v.GetString(common.CacheDirKey); dir != "" {
+		if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+			problems = append(problems, fmt.Sprintf("%s isn't a directory: %s", common.CacheDirKey, dir))
+		}
+	}
+	if file := v.GetString(aoc.SessionFileKey); file != "" {
+		if _, err := os.ReadFile(file); err != nil {
+			problems = append(problems, fmt.Sprintf("%s can't be read: %s", aoc.SessionFileKey, err))
+		}
+	}
+
+	for _, s := range r.Solvers() {
+		if f, ok := s.(common.Flagger); ok {
+			for _, err := range f.ValidateConfig(h) {
+				problems = append(problems, err.Error())
+			}
+		}
+	}
+
+	if path == "" {
+		path = "none"
+	}
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf("config file: %s\n", path))
+	for _, p := range problems {
+		out.WriteString(fmt.Sprintf("  - %s\n", p))
+	}
+	if len(problems) == 0 {
+		out.WriteString("config ok\n")
+	}
+	_, err = h.Streams.Out.Write([]byte(out.String()))
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return ErrInvalidConfig{Count: len(problems)}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConfig is a test for the config file and the precedence of flags, env, file and defaults
+func TestConfig(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	contents := "output: yaml\nlog-level: debug\nworkers: 3\nbogus: 1\nday4-wildcard: \"##\"\n"
+	assert.Nil(t, os.WriteFile(config, []byte(contents), 0o644))
+	t.Setenv("AOC2024_OUTPUT", "json")
+	// generic names aren't settings
+	t.Setenv("WORKERS", "7")
+	testCases := []struct {
+		name        string
+		args        []string
+		expectedErr error
+		expectedOut []string
+	}{
+		{
+			name: "show",
+			args: []string{"config", "show", "--log-level", "warn"},
+			expectedOut: []string{
+				"config file: " + config,
+				"output json env AOC2024_OUTPUT",
+				"log-level warn flag AOC2024_LOG_LEVEL",
+				"workers 3 file AOC2024_WORKERS",
+				"ledger answers.json default AOC2024_LEDGER",
+				"aoc-session default AOC2024_AOC_SESSION",
+				"day4-wildcard ## file AOC2024_DAY4_WILDCARD",
+			},
+		},
+		{
+			name:        "validate",
+			args:        []string{"config", "validate"},
+			expectedErr: ErrInvalidConfig{Count: 2},
+			expectedOut: []string{
+				"  - unknown key in config file: bogus",
+				"  - wildcard \"##\" must be a single character",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			s := test.NewTestStreams()
+			l := test.NewTestSlog(s.Streams)
+			v := test.NewTestViper(t)
+			common.BindEnv(v)
+			h, err := common.NewHelpers(s.Streams, v, l)
+			if err != nil {
+				l.Error(err.Error())
+				t.Log(err)
+				t.Fail()
+			}
+			rootCmd, err := NewRootCmd(h)
+			assert.Nil(t, err)
+			rootCmd.SetArgs(append(tc.args, "--config", config))
+			// Act
+			err = rootCmd.Execute()
+			// Assert
+			assert.Equal(t, tc.expectedErr, err)
+			lines := make([]string, 0)
+			for _, line := range strings.Split(s.BufInOut.String(), "\n") {
+				lines = append(lines, strings.Join(strings.Fields(line), " "))
+			}
+			for _, expected := range tc.expectedOut {
+				assert.Contains(t, lines, strings.Join(strings.Fields(expected), " "))
+			}
+		})
+	}
+}
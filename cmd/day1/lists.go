@@ -3,10 +3,9 @@ package day1
 import (
 	"fmt"
 	"slices"
-	"strconv"
-	"strings"
 
 	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/parse"
 )
 
 // Lists has the left and right lists
@@ -25,17 +24,17 @@ func GetLists(h *common.Helpers, in *common.File) (*Lists, error) {
 	}
 	numLists := len(rawLists)
 	if numLists > 2 {
-		h.Logger.Error(fmt.Sprintf("Too many lists: %d", len(rawLists)))
+		h.Logger.Error("Too many lists", "lists", numLists)
 		return nil, fmt.Errorf("Too many lists")
 	}
 	if numLists < 2 {
-		h.Logger.Error(fmt.Sprintf("Not enough lists: %d", len(rawLists)))
+		h.Logger.Error("Not enough lists", "lists", numLists)
 		return nil, fmt.Errorf("Not enough lists")
 	}
 	leftLen := len(rawLists[0])
 	rightLen := len(rawLists[1])
 	if leftLen != rightLen {
-		h.Logger.Error(fmt.Sprintf("Lists are not the same length: %d != %d", leftLen, rightLen))
+		h.Logger.Error("Lists are not the same length", "left", leftLen, "right", rightLen)
 		return nil, fmt.Errorf("Lists are not the same length")
 	}
 	lists := &Lists{
@@ -53,70 +52,38 @@ func parseInput(h *common.Helpers, in *common.File) ([][]int, error) {
 	Left := []int{}
 	Right := []int{}
 
-	h.Logger.Debug(fmt.Sprintf("Parsing input: %s", in.Name))
-	contents := string(in.Contents)
-	h.Logger.Debug(fmt.Sprintf("Contents: %s", contents))
-	lines := h.GetLines(contents)
-	h.Logger.Debug(fmt.Sprintf("Lines: %v", lines))
-	h.Logger.Debug(fmt.Sprintf("Num lines: %d", len(lines)))
+	lines := parse.Lines(in.Name, in.Contents)
+	h.Logger.Debug("Parsing input", "file", in.Name, "bytes", len(in.Contents), "lines", len(lines))
 
 	for _, line := range lines {
-		// Skip empty lines
-		if line == "" {
-			continue
-		}
-		// Split the line into words
-		words := strings.Split(line, " ")
-		added := 0
-		for _, word := range words {
-			// Skip empty words
-			if word == "" {
-				continue
-			}
-			// Convert the word to a number
-			num, err := strconv.Atoi(word)
-			if err != nil {
-				return nil, err
-			}
-			// Add the number to the left or right list
-			switch added {
-			case 0:
-				Left = append(Left, num)
-				added++
-			case 1:
-				Right = append(Right, num)
-				added++
-			default:
-				return nil, fmt.Errorf("Too many numbers in line")
-			}
-		}
-		// Check if we have enough numbers
-		if added != 2 {
-			return nil, fmt.Errorf("Not enough numbers in line")
+		// every line is a number from the left list and one from the right
+		nums, err := line.ExactInts(2)
+		if err != nil {
+			h.Logger.Error("Error parsing line", common.ErrAttr, err)
+			return nil, err
 		}
+		Left = append(Left, nums[0])
+		Right = append(Right, nums[1])
 	}
-	h.Logger.Debug(fmt.Sprintf("Left: %v", Left))
-	h.Logger.Debug(fmt.Sprintf("Right: %v", Right))
+	h.Logger.Debug("Parsed lists", "left", len(Left), "right", len(Right))
 
 	return [][]int{Left, Right}, nil
 }
 
 // Sort sorts the lists
 func (l *Lists) Sort(h *common.Helpers) {
-	h.Logger.Debug("Sorting lists")
-	sortList(h, l.Left)
-	sortList(h, l.Right)
+	h.Logger.Debug("Sorting lists", "entries", len(l.Left))
+	sortList(l.Left)
+	sortList(l.Right)
 }
 
 // sortList sorts a list
-func sortList(h *common.Helpers, l []int) {
-	h.Logger.Debug(fmt.Sprintf("Sorting list: %v", l))
+func sortList(l []int) {
 	slices.Sort(l)
 }
 
 // diffListEntry returns the difference between the left and right lists at index i
-func diffListEntry(h *common.Helpers, l *Lists, i int) int {
-	h.Logger.Debug(fmt.Sprintf("DiffListEntry: %v", l))
+func diffListEntry(l *Lists, i int) int {
 	if l.Left[i] < l.Right[i] {
 		return l.Right[i] - l.Left[i]
 	}
@@ -125,28 +92,27 @@ func diffListEntry(h *common.Helpers, l *Lists, i int) int {
 
 // DiffList returns the difference between the left and right lists
 func (l *Lists) DiffList(h *common.Helpers) int {
-	h.Logger.Debug(fmt.Sprintf("DiffList: %v", l))
 	diff := 0
 	for i := 0; i < len(l.Left); i++ {
-		diff += diffListEntry(h, l, i)
+		diff += diffListEntry(l, i)
 	}
+	h.Logger.Debug("Diffed lists", "entries", len(l.Left), "diff", diff)
 	return diff
 }
 
 // indexInstances returns the number of instances of a number in a list
 func (l *Lists) indexInstances(h *common.Helpers) {
-	h.Logger.Debug(fmt.Sprintf("IndexInstances: %v", l))
 	for _, num := range l.Left {
 		l.LeftCounts[num]++
 	}
 	for _, num := range l.Right {
 		l.RightCounts[num]++
 	}
+	h.Logger.Debug("Indexed instances", "left", len(l.LeftCounts), "right", len(l.RightCounts))
 }
 
 // weightOfIndex returns the weight of an index
-func (l *Lists) weightOfIndex(h *common.Helpers, i int) int {
-	h.Logger.Debug(fmt.Sprintf("WeightOfIndex: %v", i))
+func (l *Lists) weightOfIndex(i int) int {
 	leftValue := l.Left[i]
 	rightCount := l.RightCounts[leftValue]
 	return leftValue * rightCount
@@ -154,10 +120,10 @@ func (l *Lists) weightOfIndex(h *common.Helpers, i int) int {
 
 // CountCommonEntries returns the product of the number of a common entry in the left and right lists
 func (l *Lists) CountCommonEntries(h *common.Helpers) int {
-	h.Logger.Debug(fmt.Sprintf("CountCommonEntries: %v", l))
 	total := 0
 	for i := range l.Left {
-		total += l.weightOfIndex(h, i)
+		total += l.weightOfIndex(i)
 	}
+	h.Logger.Debug("Counted common entries", "entries", len(l.Left), "total", total)
 	return total
 }
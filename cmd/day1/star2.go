@@ -1,32 +1,15 @@
 package day1
 
 import (
-	"fmt"
-
 	"github.com/mrlunchbox777/2024-advent-of-code/common"
-	"github.com/spf13/cobra"
 )
 
-// NewStar2Cmd creates a new star2 command
-func NewStar2Cmd(h *common.Helpers) *cobra.Command {
-	star2Cmd := &cobra.Command{
-		Use:   star2,
-		Short: star2,
-		Long:  star2,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return Star2(h)
-		},
-	}
-	return star2Cmd
-}
-
 // Star2 is the solution for the second star
-func Star2(h *common.Helpers) error {
-	l, err := getInputs(h, star1)
+func (s *Solver) Star2(h *common.Helpers, in any) (int, error) {
+	l, err := common.InputAs[*Lists](in)
 	if err != nil {
-		h.Logger.Error(fmt.Sprintf("Error getting inputs: %s", err))
-		return err
+		h.Logger.Error("Error getting inputs", common.ErrAttr, err)
+		return 0, err
 	}
-	_, err = h.Streams.Out.Write([]byte(fmt.Sprintf("%s Star 2: %d\n", human, l.CountCommonEntries(h))))
-	return err
+	return l.CountCommonEntries(h), nil
 }
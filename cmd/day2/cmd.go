@@ -1,47 +1,36 @@
 package day2
 
 import (
-	"fmt"
-
 	"github.com/mrlunchbox777/2024-advent-of-code/common"
-	"github.com/spf13/cobra"
 )
 
 const (
-	use   = "day2"
-	human = "Day 2"
-	star1 = "star1"
-	star2 = "star2"
+	day   = 2
+	title = "Red-Nosed Reports"
 )
 
-// NewCmd creates a new day1 command
-func NewCmd(h *common.Helpers) *cobra.Command {
-	day1Cmd := &cobra.Command{
-		Use:   use,
-		Short: human,
-		Long:  human,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return fmt.Errorf("No subcommand given")
-		},
-	}
+// Solver is the solver for day 2
+type Solver struct{}
 
-	day1Cmd.AddCommand(NewStar1Cmd(h))
-	day1Cmd.AddCommand(NewStar2Cmd(h))
+// NewSolver creates a new day 2 solver
+func NewSolver() *Solver {
+	return &Solver{}
+}
 
-	return day1Cmd
+// Info returns the metadata for day 2
+func (s *Solver) Info() common.SolverInfo {
+	return common.SolverInfo{
+		Day:   day,
+		Title: title,
+	}
 }
 
-func getInputs(h *common.Helpers, star string) (*Reports, error) {
-	resourceName := fmt.Sprintf("%s-%s", use, star1)
-	name := fmt.Sprintf("%s-%s", use, star)
-	h.Logger.Info(name)
-	f := h.Resources.GetFile(h, resourceName)
+// Parse parses the input into reports
+func (s *Solver) Parse(h *common.Helpers, f *common.File) (any, error) {
 	r, err := GetReports(h, f)
 	if err != nil {
-		h.Logger.Error(fmt.Sprintf("Error getting reports: %s", err))
+		h.Logger.Error("Error getting reports", common.ErrAttr, err)
 		return nil, err
 	}
-	// print the lists
-	h.Logger.Debug(fmt.Sprintf("Reports: %v", r))
 	return r, nil
 }
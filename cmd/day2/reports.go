@@ -1,11 +1,8 @@
 package day2
 
 import (
-	"fmt"
-	"strconv"
-	"strings"
-
 	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/parse"
 )
 
 // Level is an int that represents the level of the report
@@ -19,35 +16,23 @@ type Reports []Report
 
 // GetReports returns a slice of reports
 func GetReports(h *common.Helpers, in *common.File) (*Reports, error) {
-	h.Logger.Debug("Getting reports")
 	return parseInput(h, in)
 }
 
 // parseInput parses the input file and returns the reports
 func parseInput(h *common.Helpers, in *common.File) (*Reports, error) {
-	h.Logger.Debug("Parsing input")
 	reports := &Reports{}
-	contents := string(in.Contents)
-	lines := h.GetLines(contents)
+	lines := parse.Lines(in.Name, in.Contents)
+	h.Logger.Debug("Parsing input", "file", in.Name, "lines", len(lines))
 	for _, l := range lines {
-		// Skip empty lines
-		if l == "" {
-			continue
+		levels, err := l.FieldInts()
+		if err != nil {
+			h.Logger.Error("Error parsing levels", common.ErrAttr, err)
+			return nil, err
 		}
-		rawLevels := strings.Split(l, " ")
-		r := Report{}
-		for _, rl := range rawLevels {
-			// Skip empty levels
-			if rl == "" {
-				continue
-			}
-			// parse the level
-			intLevel, err := strconv.Atoi(rl)
-			if err != nil {
-				h.Logger.Error(fmt.Sprintf("Error parsing level: %s", err))
-				return nil, err
-			}
-			r = append(r, Level(intLevel))
+		r := make(Report, 0, len(levels))
+		for _, level := range levels {
+			r = append(r, Level(level))
 		}
 		*reports = append(*reports, r)
 	}
@@ -56,10 +41,8 @@ func parseInput(h *common.Helpers, in *common.File) (*Reports, error) {
 
 // IsSafe returns true if the report is safe
 func (r *Report) IsSafe(h *common.Helpers, dampener bool) bool {
-	h.Logger.Debug(fmt.Sprintf("Checking if report is safe, dampener: %t", dampener))
-	baseRun := r.singleRunIsSafe(h)
+	baseRun := r.singleRunIsSafe()
 	if dampener {
-		h.Logger.Debug("Dampening")
 		for i := 0; i < len(*r); i++ {
 			// copy the report
 			dampened := make(Report, len(*r))
@@ -67,7 +50,7 @@ func (r *Report) IsSafe(h *common.Helpers, dampener bool) bool {
 			// remove the level at i
 			dampened = append(dampened[:i], dampened[i+1:]...)
 			// check if the dampened report is safe
-			if dampened.singleRunIsSafe(h) {
+			if dampened.singleRunIsSafe() {
 				return true
 			}
 		}
@@ -76,8 +59,7 @@ func (r *Report) IsSafe(h *common.Helpers, dampener bool) bool {
 }
 
 // singleRunIsSafe returns true if the report is safe
-func (r *Report) singleRunIsSafe(h *common.Helpers) bool {
-	h.Logger.Debug("Checking if report is safe")
+func (r *Report) singleRunIsSafe() bool {
 	count := 0
 	increasing := false
 	prev := Level(0)
@@ -111,12 +93,12 @@ func (r *Report) singleRunIsSafe(h *common.Helpers) bool {
 
 // CountSafeEntries returns the number of safe reports
 func (r *Reports) CountSafeEntries(h *common.Helpers, dampener bool) int {
-	h.Logger.Debug("Counting safe entries")
 	count := 0
 	for _, report := range *r {
 		if report.IsSafe(h, dampener) {
 			count++
 		}
 	}
+	h.Logger.Debug("Counted safe entries", "dampener", dampener, "reports", len(*r), "safe", count)
 	return count
 }
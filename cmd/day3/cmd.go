@@ -1,47 +1,36 @@
 package day3
 
 import (
-	"fmt"
-
 	"github.com/mrlunchbox777/2024-advent-of-code/common"
-	"github.com/spf13/cobra"
 )
 
 const (
-	use   = "day3"
-	human = "Day 3"
-	star1 = "star1"
-	star2 = "star2"
+	day   = 3
+	title = "Mull It Over"
 )
 
-// NewCmd creates a new day1 command
-func NewCmd(h *common.Helpers) *cobra.Command {
-	day1Cmd := &cobra.Command{
-		Use:   use,
-		Short: human,
-		Long:  human,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return fmt.Errorf("No subcommand given")
-		},
-	}
+// Solver is the solver for day 3
+type Solver struct{}
 
-	day1Cmd.AddCommand(NewStar1Cmd(h))
-	day1Cmd.AddCommand(NewStar2Cmd(h))
+// NewSolver creates a new day 3 solver
+func NewSolver() *Solver {
+	return &Solver{}
+}
 
-	return day1Cmd
+// Info returns the metadata for day 3
+func (s *Solver) Info() common.SolverInfo {
+	return common.SolverInfo{
+		Day:   day,
+		Title: title,
+	}
 }
 
-func getInputs(h *common.Helpers, star string) (*Memory, error) {
-	resourceName := fmt.Sprintf("%s-%s", use, star1)
-	name := fmt.Sprintf("%s-%s", use, star)
-	h.Logger.Info(name)
-	f := h.Resources.GetFile(h, resourceName)
-	r, err := GetMemory(h, f)
+// Parse parses the input into memory
+func (s *Solver) Parse(h *common.Helpers, f *common.File) (any, error) {
+	m, err := GetMemory(h, f)
 	if err != nil {
-		h.Logger.Error(fmt.Sprintf("Error getting memory: %s", err))
+		h.Logger.Error("Error getting memory", common.ErrAttr, err)
 		return nil, err
 	}
-	// print the lists
-	h.Logger.Debug(fmt.Sprintf("Memory: %v", r))
-	return r, nil
+	return m, nil
 }
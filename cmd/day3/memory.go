@@ -1,10 +1,10 @@
 package day3
 
 import (
-	"fmt"
 	"regexp"
 
 	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/parse"
 )
 
 const (
@@ -23,6 +23,8 @@ type Memory struct {
 	Raw             string
 	RawGoodCommands []string
 	GoodCommands    []Command
+	// rawOffsets are where each raw good command starts in the raw memory
+	rawOffsets []int
 }
 
 // Command is a struct that contains a command
@@ -34,43 +36,65 @@ type Command struct {
 
 // GetMemory returns a new memory struct
 func GetMemory(h *common.Helpers, in *common.File) (*Memory, error) {
-	h.Logger.Debug("Getting memory")
 	return parseInput(h, in)
 }
 
 // parseInput parses the input file and returns the memory
 func parseInput(h *common.Helpers, in *common.File) (*Memory, error) {
-	h.Logger.Debug("Parsing input")
+	h.Logger.Debug("Parsing input", "file", in.Name, "bytes", len(in.Contents))
 	m := &Memory{
 		Raw: string(in.Contents),
 	}
+	err := m.prepareMemory(h, in.Name)
+	if err != nil {
+		h.Logger.Error("Error preparing memory", common.ErrAttr, err)
+		return nil, err
+	}
 	return m, nil
 }
 
 // findGoodCommands returns the good commands
 func (m *Memory) findGoodCommands(h *common.Helpers) {
-	h.Logger.Debug("Finding good commands")
 	r := regexp.MustCompile(allCommandsMatch)
-	m.RawGoodCommands = r.FindAllString(m.Raw, -1)
-	h.Logger.Debug(fmt.Sprintf("Raw good commands: %v", m.RawGoodCommands))
+	for _, loc := range r.FindAllStringIndex(m.Raw, -1) {
+		m.RawGoodCommands = append(m.RawGoodCommands, m.Raw[loc[0]:loc[1]])
+		m.rawOffsets = append(m.rawOffsets, loc[0])
+	}
+	h.Logger.Debug("Found good commands", "commands", len(m.RawGoodCommands))
 }
 
-// parseGoodCommands parses the good commands
-func (m *Memory) parseGoodCommands(h *common.Helpers) {
-	h.Logger.Debug("Parsing good commands")
-	for _, c := range m.RawGoodCommands {
+// parseGoodCommands parses the good commands, resource is only used for errors
+func (m *Memory) parseGoodCommands(h *common.Helpers, resource string) error {
+	for i, c := range m.RawGoodCommands {
 		rm := regexp.MustCompile(mulMatch)
-		matches := rm.FindStringSubmatch(c)
-		if len(matches) > 0 {
+		mulMatches := rm.FindStringSubmatchIndex(c)
+		if len(mulMatches) > 0 {
+			// arg parses the nth submatch, errors point at it in the raw memory
+			arg := func(n int) (int, error) {
+				start, end := mulMatches[2*n], mulMatches[2*n+1]
+				v, err := parse.Int(c[start:end])
+				if err != nil {
+					return 0, parse.At(resource, []byte(m.Raw), m.rawOffsets[i]+start, err)
+				}
+				return v, nil
+			}
+			arg1, err := arg(2)
+			if err != nil {
+				return err
+			}
+			arg2, err := arg(3)
+			if err != nil {
+				return err
+			}
 			m.GoodCommands = append(m.GoodCommands, Command{
-				Op:   matches[1],
-				Arg1: h.ToInt(matches[2]),
-				Arg2: h.ToInt(matches[3]),
+				Op:   c[mulMatches[2]:mulMatches[3]],
+				Arg1: arg1,
+				Arg2: arg2,
 			})
 			continue
 		}
 		rd := regexp.MustCompile(doMatch)
-		matches = rd.FindStringSubmatch(c)
+		matches := rd.FindStringSubmatch(c)
 		if len(matches) > 0 {
 			m.GoodCommands = append(m.GoodCommands, Command{
 				Op: matches[1],
@@ -86,20 +110,18 @@ func (m *Memory) parseGoodCommands(h *common.Helpers) {
 			continue
 		}
 	}
-	h.Logger.Debug(fmt.Sprintf("Good commands: %v", m.GoodCommands))
+	h.Logger.Debug("Parsed good commands", "commands", len(m.GoodCommands))
+	return nil
 }
 
-// prepareMemory prepares the memory
-func (m *Memory) prepareMemory(h *common.Helpers) {
-	h.Logger.Debug("Preparing memory")
+// prepareMemory prepares the memory, resource is only used for errors
+func (m *Memory) prepareMemory(h *common.Helpers, resource string) error {
 	m.findGoodCommands(h)
-	m.parseGoodCommands(h)
+	return m.parseGoodCommands(h, resource)
 }
 
 // SumOfCommands returns the sum of the commands
 func (m *Memory) SumOfCommands(h *common.Helpers, flowControl bool) int {
-	h.Logger.Debug("Summing commands")
-	m.prepareMemory(h)
 	sum := 0
 	enabled := true
 	for _, c := range m.GoodCommands {
@@ -115,5 +137,6 @@ func (m *Memory) SumOfCommands(h *common.Helpers, flowControl bool) int {
 			sum += c.Arg1 * c.Arg2
 		}
 	}
+	h.Logger.Debug("Summed commands", "flowControl", flowControl, "sum", sum)
 	return sum
 }
@@ -1,32 +1,15 @@
 package day3
 
 import (
-	"fmt"
-
 	"github.com/mrlunchbox777/2024-advent-of-code/common"
-	"github.com/spf13/cobra"
 )
 
-// NewStar2Cmd creates a new star2 command
-func NewStar2Cmd(h *common.Helpers) *cobra.Command {
-	star2Cmd := &cobra.Command{
-		Use:   star2,
-		Short: star2,
-		Long:  star2,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return Star2(h)
-		},
-	}
-	return star2Cmd
-}
-
 // Star2 is the solution for the second star
-func Star2(h *common.Helpers) error {
-	r, err := getInputs(h, star1)
+func (s *Solver) Star2(h *common.Helpers, in any) (int, error) {
+	m, err := common.InputAs[*Memory](in)
 	if err != nil {
-		h.Logger.Error(fmt.Sprintf("Error getting inputs: %s", err))
-		return err
+		h.Logger.Error("Error getting inputs", common.ErrAttr, err)
+		return 0, err
 	}
-	_, err = h.Streams.Out.Write([]byte(fmt.Sprintf("%s Star 1: %d\n", human, r.SumOfCommands(h, true))))
-	return err
+	return m.SumOfCommands(h, true), nil
 }
@@ -0,0 +1,121 @@
+package day4
+
+import (
+	"unicode/utf8"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common/grid"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/parse"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// noSymbol is the key of a letter no cell of the puzzle has, it never matches
+	noSymbol = Symbol(-1)
+)
+
+// Symbol is a letter of a puzzle interned to an int, so the scanner compares cells as ints
+type Symbol int32
+
+// Alphabet is the struct for the letters of a puzzle, each letter is a grapheme cluster (see parse.Graphemes).
+// Every letter has a symbol, and a key that's the same for every letter it matches, i.e. its symbol, or with Fold the
+// symbol of its case folded form
+type Alphabet struct {
+	Fold    bool
+	letters []string
+	symbols map[string]Symbol
+	// ascii are the symbols of the ascii letters, so the common case doesn't hash a string per cell
+	ascii [utf8.RuneSelf]Symbol
+	// keys are the key of each symbol
+	keys []Symbol
+	// folded are the keys by the case folded form of a letter
+	folded map[string]Symbol
+}
+
+// NewAlphabet creates a new empty alphabet, fold matches letters case insensitively
+func NewAlphabet(fold bool) *Alphabet {
+	a := &Alphabet{
+		Fold:    fold,
+		letters: make([]string, 0),
+		symbols: make(map[string]Symbol),
+		keys:    make([]Symbol, 0),
+		folded:  make(map[string]Symbol),
+	}
+	for i := range a.ascii {
+		a.ascii[i] = noSymbol
+	}
+	return a
+}
+
+// Intern returns the symbol of a letter, adding it to the alphabet if it's new
+func (a *Alphabet) Intern(letter string) Symbol {
+	if len(letter) == 1 && letter[0] < utf8.RuneSelf && a.ascii[letter[0]] != noSymbol {
+		return a.ascii[letter[0]]
+	}
+	if s, ok := a.symbols[letter]; ok {
+		return s
+	}
+	s := Symbol(len(a.letters))
+	a.letters = append(a.letters, letter)
+	a.symbols[letter] = s
+	if len(letter) == 1 && letter[0] < utf8.RuneSelf {
+		a.ascii[letter[0]] = s
+	}
+	key := s
+	if a.Fold {
+		f := a.fold(letter)
+		k, ok := a.folded[f]
+		if !ok {
+			k = Symbol(len(a.folded))
+			a.folded[f] = k
+		}
+		key = k
+	}
+	a.keys = append(a.keys, key)
+	return s
+}
+
+// fold returns the case folded form of a letter
+func (a *Alphabet) fold(letter string) string {
+	return norm.NFC.String(cases.Fold().String(letter))
+}
+
+// Letter returns the letter of a symbol
+func (a *Alphabet) Letter(s Symbol) string {
+	return a.letters[s]
+}
+
+// Key returns the key of a letter, noSymbol if no letter of the alphabet matches it
+func (a *Alphabet) Key(letter string) Symbol {
+	letter = norm.NFC.String(letter)
+	if a.Fold {
+		if k, ok := a.folded[a.fold(letter)]; ok {
+			return k
+		}
+		return noSymbol
+	}
+	if s, ok := a.symbols[letter]; ok {
+		return a.keys[s]
+	}
+	return noSymbol
+}
+
+// Keys returns the key of each letter of a word
+func (a *Alphabet) Keys(word string) []Symbol {
+	letters := parse.Graphemes(word)
+	keys := make([]Symbol, 0, len(letters))
+	for _, l := range letters {
+		keys = append(keys, a.Key(l))
+	}
+	return keys
+}
+
+// KeyGrid returns the grid of the keys of a grid of symbols, the grid itself if every symbol is its own key
+func (a *Alphabet) KeyGrid(g *grid.Grid[Symbol]) *grid.Grid[Symbol] {
+	if !a.Fold {
+		return g
+	}
+	return grid.Map(g, func(s Symbol) Symbol {
+		return a.keys[s]
+	})
+}
@@ -0,0 +1,62 @@
+package day4
+
+import (
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCountWordUnicode is a test for counting words of grapheme clusters, with and without case folding
+func TestCountWordUnicode(t *testing.T) {
+	testCases := []struct {
+		name       string
+		input      string
+		word       string
+		ignoreCase bool
+		expected   int
+	}{
+		{name: "greek", input: "ΣΟΦΙΑ\nΑΙΦΟΣ\n", word: "ΣΟΦΙΑ", expected: 2},
+		{name: "cyrillic_diagonal", input: "Мир\nаир\nаар\n", word: "Мир", expected: 2},
+		{name: "decomposed_grid", input: "cafe\u0301\n", word: "caf\u00e9", expected: 1},
+		{name: "decomposed_word", input: "caf\u00e9\n", word: "cafe\u0301", expected: 1},
+		{name: "case_sensitive", input: "xmas\nXMAS\n", word: "XMAS", expected: 1},
+		{name: "ignore_case", input: "xmas\nXMAS\n", word: "XMAS", ignoreCase: true, expected: 2},
+		{name: "ignore_case_final_sigma", input: "ΟΔΟΣ\nοδος\n", word: "ΟΔΟΣ", ignoreCase: true, expected: 2},
+		{name: "case_sensitive_greek", input: "ΣΟΦΙΑ\nσοφια\n", word: "σοφια", expected: 1},
+		{name: "ignore_case_non_ascii", input: "ΣΟΦΙΑ\nσοφια\n", word: "σοφια", ignoreCase: true, expected: 2},
+		{name: "ignore_case_unknown_letter", input: "ABC\n", word: "ABD", ignoreCase: true, expected: 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			h := newTestHelpers(t)
+			h.Viper.Set(IgnoreCaseKey, tc.ignoreCase)
+			p, err := GetPuzzle(h, &common.File{Name: t.Name(), Contents: []byte(tc.input)})
+			assert.Nil(t, err)
+			// Act
+			result, err := p.CountWord(h, tc.word)
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+// TestAlphabet is a test for the letters and keys of an Alphabet
+func TestAlphabet(t *testing.T) {
+	// Arrange
+	a := NewAlphabet(true)
+	// Act
+	upper := a.Intern("Σ")
+	lower := a.Intern("σ")
+	final := a.Intern("ς")
+	// Assert
+	assert.Equal(t, "Σ", a.Letter(upper))
+	assert.Equal(t, "ς", a.Letter(final))
+	assert.NotEqual(t, upper, lower)
+	assert.Equal(t, a.Key("Σ"), a.Key("σ"))
+	assert.Equal(t, a.Key("σ"), a.Key("ς"))
+	assert.Equal(t, noSymbol, a.Key("x"))
+	assert.Equal(t, []Symbol{a.Key("σ"), noSymbol}, a.Keys("ΣX"))
+}
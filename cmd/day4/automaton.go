@@ -0,0 +1,86 @@
+package day4
+
+// automaton is an Aho-Corasick automaton, it finds every word of a dictionary in a single pass over a line of letters
+type automaton struct {
+	nodes []automatonNode
+	// lengths are the number of letters in each word
+	lengths []int
+}
+
+// automatonNode is a state of the automaton, the letters read so far that are a prefix of a word
+type automatonNode struct {
+	next map[Symbol]int
+	// fail is the state of the longest suffix of this state that is also a prefix of a word
+	fail int
+	// out are the indexes of the words that end in this state, including through fail
+	out []int
+}
+
+// newAutomaton builds an automaton from words, each a slice of the keys of its letters
+func newAutomaton(words [][]Symbol) *automaton {
+	a := &automaton{
+		nodes:   []automatonNode{{next: make(map[Symbol]int)}},
+		lengths: make([]int, 0, len(words)),
+	}
+	// build the trie of the words
+	for i, word := range words {
+		a.lengths = append(a.lengths, len(word))
+		state := 0
+		for _, letter := range word {
+			next, ok := a.nodes[state].next[letter]
+			if !ok {
+				next = len(a.nodes)
+				a.nodes = append(a.nodes, automatonNode{next: make(map[Symbol]int)})
+				a.nodes[state].next[letter] = next
+			}
+			state = next
+		}
+		a.nodes[state].out = append(a.nodes[state].out, i)
+	}
+	// link each state to its longest proper suffix, breadth first so the suffixes are linked first
+	queue := make([]int, 0, len(a.nodes))
+	for _, child := range a.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for letter, child := range a.nodes[state].next {
+			suffix := a.nodes[state].fail
+			for suffix > 0 && !a.has(suffix, letter) {
+				suffix = a.nodes[suffix].fail
+			}
+			fail := 0
+			// a child of the root is its own suffix otherwise
+			if next, ok := a.nodes[suffix].next[letter]; ok && next != child {
+				fail = next
+			}
+			a.nodes[child].fail = fail
+			a.nodes[child].out = append(a.nodes[child].out, a.nodes[fail].out...)
+			queue = append(queue, child)
+		}
+	}
+	return a
+}
+
+// has checks if a state has a transition for a letter
+func (a *automaton) has(state int, letter Symbol) bool {
+	_, ok := a.nodes[state].next[letter]
+	return ok
+}
+
+// step returns the state after reading a letter
+func (a *automaton) step(state int, letter Symbol) int {
+	for state > 0 && !a.has(state, letter) {
+		state = a.nodes[state].fail
+	}
+	if next, ok := a.nodes[state].next[letter]; ok {
+		return next
+	}
+	return 0
+}
+
+// ends returns the indexes of the words that end in a state
+func (a *automaton) ends(state int) []int {
+	return a.nodes[state].out
+}
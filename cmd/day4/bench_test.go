@@ -0,0 +1,97 @@
+package day4
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/test"
+)
+
+// benchHelpers returns helpers that only log errors, so logging isn't benchmarked
+func benchHelpers(b *testing.B) *common.Helpers {
+	s := test.NewTestStreams()
+	v := test.NewTestViper(b)
+	v.Set(common.LogLevelKey, "error")
+	l, _, err := common.NewLogger(s.ErrOut, v)
+	if err != nil {
+		b.Fatal(err)
+	}
+	h, err := common.NewHelpers(s.Streams, v, l)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return h
+}
+
+// synthetic returns a square grid of random X, M, A and S letters, the same for every size and seed
+func synthetic(size int, seed uint64) []byte {
+	r := rand.New(rand.NewPCG(seed, seed))
+	var sb strings.Builder
+	sb.Grow(size * (size + 1))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			sb.WriteByte("XMAS"[r.IntN(4)])
+		}
+		sb.WriteByte('\n')
+	}
+	return []byte(sb.String())
+}
+
+// benchInputs returns the puzzle input and synthetic grids to benchmark against
+func benchInputs(b *testing.B, h *common.Helpers) []*common.File {
+	in, err := common.GetInput(h, NewSolver())
+	if err != nil {
+		b.Fatal(err)
+	}
+	files := []*common.File{in}
+	sizes := []int{1000, 5000}
+	if testing.Short() {
+		sizes = sizes[:1]
+	}
+	for _, size := range sizes {
+		files = append(files, &common.File{Name: fmt.Sprintf("synthetic-%d", size), Contents: synthetic(size, 4)})
+	}
+	return files
+}
+
+// BenchmarkDay4 benchmarks parsing and both stars of day 4 against the input and synthetic grids
+func BenchmarkDay4(b *testing.B) {
+	h := benchHelpers(b)
+	for _, f := range benchInputs(b, h) {
+		b.Run(f.Name+"/parse", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := GetPuzzle(h, f)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		p, err := GetPuzzle(h, f)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(f.Name+"/star1", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := p.CountWord(h, "XMAS")
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(f.Name+"/star2", func(b *testing.B) {
+			targets, err := ParsePatterns("xmas", []byte(xmasPattern), DefaultWildcard)
+			if err != nil {
+				b.Fatal(err)
+			}
+			for i := 0; i < b.N; i++ {
+				_, err := p.CountBlocks(h, targets, true)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -1,59 +1,41 @@
 package day4
 
 import (
-	"fmt"
-
 	"github.com/mrlunchbox777/2024-advent-of-code/common"
-	"github.com/spf13/cobra"
 )
 
 const (
-	use   = "day4"
-	human = "Day 4"
-	star1 = "star1"
-	star2 = "star2"
+	day   = 4
+	title = "Ceres Search"
 )
 
 var (
-	c_a     = Cell{Letter: "A"}
-	c_m     = Cell{Letter: "M"}
-	c_s     = Cell{Letter: "S"}
+	// c_space is the wildcard of a block pattern, it matches any letter
 	c_space = Cell{Letter: " "}
-	a       = Set{c_space, c_a, c_space}
-	ms      = Set{c_m, c_space, c_s}
-	sm      = Set{c_s, c_space, c_m}
-	mm      = Set{c_m, c_space, c_m}
-	ss      = Set{c_s, c_space, c_s}
 )
 
-// NewCmd creates a new day1 command
-func NewCmd(h *common.Helpers) *cobra.Command {
-	day1Cmd := &cobra.Command{
-		Use:   use,
-		Short: human,
-		Long:  human,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return fmt.Errorf("No subcommand given")
-		},
-	}
+// Solver is the solver for day 4
+type Solver struct{}
 
-	day1Cmd.AddCommand(NewStar1Cmd(h))
-	day1Cmd.AddCommand(NewStar2Cmd(h))
+// NewSolver creates a new day 4 solver
+func NewSolver() *Solver {
+	return &Solver{}
+}
 
-	return day1Cmd
+// Info returns the metadata for day 4
+func (s *Solver) Info() common.SolverInfo {
+	return common.SolverInfo{
+		Day:   day,
+		Title: title,
+	}
 }
 
-func getInputs(h *common.Helpers, star string) (*Puzzle, error) {
-	resourceName := fmt.Sprintf("%s-%s", use, star1)
-	name := fmt.Sprintf("%s-%s", use, star)
-	h.Logger.Info(name)
-	f := h.Resources.GetFile(h, resourceName)
+// Parse parses the input into an initialized puzzle
+func (s *Solver) Parse(h *common.Helpers, f *common.File) (any, error) {
 	p, err := GetPuzzle(h, f)
 	if err != nil {
-		h.Logger.Error(fmt.Sprintf("Error getting puzzle: %s", err))
+		h.Logger.Error("Error getting puzzle", common.ErrAttr, err)
 		return nil, err
 	}
-	// print the lists
-	h.Logger.Debug(fmt.Sprintf("Puzzle: %v", p))
 	return p, nil
 }
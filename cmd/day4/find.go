@@ -0,0 +1,160 @@
+package day4
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Found is the struct for the matches of a word or of block patterns, with their tally
+type Found struct {
+	Word    string         `json:"word,omitempty" yaml:"word,omitempty"`
+	Total   int            `json:"total" yaml:"total"`
+	Tally   map[string]int `json:"tally" yaml:"tally"`
+	Matches []*Match       `json:"matches" yaml:"matches"`
+}
+
+// Commands returns the day 4 commands beyond the stars
+func (s *Solver) Commands(h *common.Helpers) []*cobra.Command {
+	return []*cobra.Command{newFindCmd(h, s), newMatchCmd(h, s), newSearchCmd(h, s), newRenderCmd(h, s), newGenerateCmd(h)}
+}
+
+// newFindCmd creates a new find command
+func newFindCmd(h *common.Helpers, s *Solver) *cobra.Command {
+	var word string
+	findCmd := &cobra.Command{
+		Use:   "find",
+		Short: "List where a word is found in the puzzle",
+		Long:  "List where a word is found in the puzzle, with the direction and cells of each match and a tally by direction",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return find(h, s, word)
+		},
+	}
+	findCmd.Flags().StringVar(&word, "word", "XMAS", "word to find")
+	return findCmd
+}
+
+// newMatchCmd creates a new match command
+func newMatchCmd(h *common.Helpers, s *Solver) *cobra.Command {
+	var symmetry string
+	matchCmd := &cobra.Command{
+		Use:   "match",
+		Short: "List where block patterns are found in the puzzle",
+		Long: "List where the block patterns of --pattern (defaults to the star 2 X-MAS pattern) are found in the puzzle, " +
+			"with the orientation and cells of each match and a tally by orientation",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sym, err := ParseSymmetry(symmetry)
+			if err != nil {
+				return err
+			}
+			return match(h, s, sym)
+		},
+	}
+	matchCmd.Flags().StringVar(&symmetry, "symmetry", string(Rotations), fmt.Sprintf("orientations to match the patterns in, one of %v", Symmetries()))
+	return matchCmd
+}
+
+// find parses the puzzle and writes the matches of a word in the configured format
+func find(h *common.Helpers, s *Solver, word string) error {
+	return writeFound(h, s, func(h *common.Helpers, p *Puzzle) (*Found, error) {
+		matches, err := p.FindWord(h, word)
+		return &Found{Word: word, Matches: matches}, err
+	})
+}
+
+// match parses the puzzle and writes the matches of the block patterns in a symmetry in the configured format
+func match(h *common.Helpers, s *Solver, sym Symmetry) error {
+	return writeFound(h, s, func(h *common.Helpers, p *Puzzle) (*Found, error) {
+		targets, err := getPatterns(h)
+		if err != nil {
+			return nil, err
+		}
+		matches, err := p.FindBlocks(h, targets, sym)
+		return &Found{Matches: matches}, err
+	})
+}
+
+// writeFound parses the puzzle, finds matches in it with f and writes them with their tally in the configured format
+func writeFound(h *common.Helpers, s *Solver, f func(h *common.Helpers, p *Puzzle) (*Found, error)) error {
+	h = h.With(common.DayAttr, day)
+	format, err := common.GetOutputFormat(h, common.TextOutput)
+	if err != nil {
+		return err
+	}
+	p, err := loadPuzzle(h.With(common.PhaseAttr, common.ParsePhase), s)
+	if err != nil {
+		return err
+	}
+	found, err := f(h, p)
+	if err != nil {
+		return err
+	}
+	found.Total = len(found.Matches)
+	found.Tally = Tally(found.Matches)
+	return found.Render(h.Streams.Out, format)
+}
+
+// loadPuzzle gets the input and parses it into a puzzle
+func loadPuzzle(h *common.Helpers, s *Solver) (*Puzzle, error) {
+	f, err := common.GetInput(h, s)
+	if err != nil {
+		return nil, err
+	}
+	in, err := s.Parse(h, f)
+	if err != nil {
+		return nil, err
+	}
+	return common.InputAs[*Puzzle](in)
+}
+
+// Render writes the matches to a writer in a format, answer only writes the total and table is the same as text
+func (f *Found) Render(w io.Writer, format common.OutputFormat) error {
+	switch format {
+	case common.TextOutput, common.TableOutput:
+		return f.renderText(w)
+	case common.JSONOutput:
+		e := json.NewEncoder(w)
+		e.SetIndent("", "  ")
+		return e.Encode(f)
+	case common.YAMLOutput:
+		e := yaml.NewEncoder(w)
+		e.SetIndent(2)
+		err := e.Encode(f)
+		if err != nil {
+			return err
+		}
+		return e.Close()
+	case common.AnswerOutput:
+		_, err := fmt.Fprintln(w, f.Total)
+		return err
+	default:
+		return common.ErrUnknownOutput{Format: format}
+	}
+}
+
+// renderText writes a line per match, then the tally in the order the matches were found
+func (f *Found) renderText(w io.Writer) error {
+	var b strings.Builder
+	for _, m := range f.Matches {
+		name := f.Word
+		if name == "" {
+			name = fmt.Sprintf("block %d", m.Pattern)
+		}
+		cells := make([]string, 0, len(m.Cells))
+		for _, c := range m.Cells {
+			cells = append(cells, c.String())
+		}
+		fmt.Fprintf(&b, "%s %s %s: %s\n", name, m.Key(), m.Start, strings.Join(cells, " "))
+	}
+	for _, k := range TallyKeys(f.Matches) {
+		fmt.Fprintf(&b, "%s: %d\n", k, f.Tally[k])
+	}
+	fmt.Fprintf(&b, "total: %d\n", f.Total)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
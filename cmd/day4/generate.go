@@ -0,0 +1,333 @@
+package day4
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strings"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/grid"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/parse"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// DefaultAlphabet is the default alphabet the gaps of a generated puzzle are filled from
+	DefaultAlphabet = "XMAS"
+	// placeAttempts is the number of random spots a word is tried in before giving up on it
+	placeAttempts = 1000
+	// keySuffix is added to the puzzle file for the default answer key file
+	keySuffix = ".key.json"
+)
+
+// GenerateOptions is the struct for the settings of a generated puzzle
+type GenerateOptions struct {
+	Width  int
+	Height int
+	Words  []string
+	// Count is the number of times each word is placed
+	Count int
+	Seed  uint64
+	// Directions are the directions words are placed in, all 8 if empty
+	Directions []grid.Point
+	// Overlap lets placed words share cells where their letters are the same
+	Overlap bool
+	// Alphabet is the letters the gaps are filled from, each grapheme cluster is a letter
+	Alphabet string
+	// Patterns are the block patterns the answer key counts, the X-MAS pattern if empty
+	Patterns []Sets
+}
+
+// Placement is the struct for where a word was placed in a generated puzzle
+type Placement struct {
+	Word      string     `json:"word" yaml:"word"`
+	Start     grid.Point `json:"start" yaml:"start"`
+	Direction string     `json:"direction" yaml:"direction"`
+}
+
+// AnswerKey is the struct for the expected totals of a generated puzzle, they're counted by brute force rather than
+// with the scanner so they can check it
+type AnswerKey struct {
+	Seed       uint64      `json:"seed" yaml:"seed"`
+	Width      int         `json:"width" yaml:"width"`
+	Height     int         `json:"height" yaml:"height"`
+	Placements []Placement `json:"placements" yaml:"placements"`
+	// Words are the CountWord totals of each word, the filler may spell a word more times than it was placed
+	Words map[string]int `json:"words" yaml:"words"`
+	// Blocks is the CountBlocks total of the patterns in their rotations, as star 2 counts them
+	Blocks int `json:"blocks" yaml:"blocks"`
+}
+
+// Generated is the struct for a generated puzzle and its answer key
+type Generated struct {
+	Puzzle string
+	Key    *AnswerKey
+}
+
+// ErrGenerateSize is an error that is returned when a puzzle to generate has no cells
+type ErrGenerateSize struct {
+	Width  int
+	Height int
+}
+
+// Error returns the error message
+func (e ErrGenerateSize) Error() string {
+	return fmt.Sprintf("puzzle size %dx%d must be at least 1x1", e.Width, e.Height)
+}
+
+// ErrEmptyAlphabet is an error that is returned when there are no letters to fill a puzzle with
+type ErrEmptyAlphabet struct{}
+
+// Error returns the error message
+func (e ErrEmptyAlphabet) Error() string {
+	return "no letters to fill the puzzle with"
+}
+
+// ErrPlacement is an error that is returned when a word doesn't fit anywhere in a puzzle
+type ErrPlacement struct {
+	Word string
+}
+
+// Error returns the error message
+func (e ErrPlacement) Error() string {
+	return fmt.Sprintf("couldn't place %q in %d attempts, try a bigger puzzle, fewer words or overlap", e.Word, placeAttempts)
+}
+
+// Generate builds a puzzle by placing each word Count times in random spots and directions, then filling the gaps
+// with random letters of the alphabet, the same options always give the same puzzle
+func Generate(h *common.Helpers, opts GenerateOptions) (*Generated, error) {
+	if opts.Width < 1 || opts.Height < 1 {
+		return nil, ErrGenerateSize{Width: opts.Width, Height: opts.Height}
+	}
+	alphabet := parse.Graphemes(opts.Alphabet)
+	if len(alphabet) == 0 {
+		return nil, ErrEmptyAlphabet{}
+	}
+	dirs := opts.Directions
+	if len(dirs) == 0 {
+		dirs = grid.Directions()
+	}
+	patterns := opts.Patterns
+	if len(patterns) == 0 {
+		var err error
+		patterns, err = ParsePatterns("xmas", []byte(xmasPattern), DefaultWildcard)
+		if err != nil {
+			return nil, err
+		}
+	}
+	r := rand.New(rand.NewPCG(opts.Seed, opts.Seed))
+	g := grid.New[string](opts.Width, opts.Height)
+	key := &AnswerKey{Seed: opts.Seed, Width: opts.Width, Height: opts.Height, Placements: make([]Placement, 0)}
+	placed := make(map[Placement]bool)
+	// place the words in turn rather than each word Count times in a row, so no word gets all the room
+	for range opts.Count {
+		for _, word := range opts.Words {
+			placement, err := place(r, g, word, dirs, opts.Overlap, placed)
+			if err != nil {
+				h.Logger.Error("Error placing word", common.ErrAttr, err)
+				return nil, err
+			}
+			placed[placement] = true
+			key.Placements = append(key.Placements, placement)
+		}
+	}
+	for pt, letter := range g.All() {
+		if letter == "" {
+			g.Set(pt, alphabet[r.IntN(len(alphabet))])
+		}
+	}
+	key.Words = make(map[string]int)
+	for _, word := range opts.Words {
+		key.Words[word] = bruteCountWord(g, parse.Graphemes(word))
+	}
+	blocks, err := bruteCountBlocks(g, patterns)
+	if err != nil {
+		return nil, err
+	}
+	key.Blocks = blocks
+	var b strings.Builder
+	for _, row := range g.Rows() {
+		b.WriteString(strings.Join(row, ""))
+		b.WriteString("\n")
+	}
+	h.Logger.Debug("Generated puzzle", "width", opts.Width, "height", opts.Height, "placements", len(key.Placements),
+		"blocks", key.Blocks)
+	return &Generated{Puzzle: b.String(), Key: key}, nil
+}
+
+// place puts a word in the first random spot and direction it fits in, letters of other words are only written
+// over when overlapping and they're the same. A word is never placed exactly where it already was, so every
+// placement is a match of its own
+func place(r *rand.Rand, g *grid.Grid[string], word string, dirs []grid.Point, overlap bool, placed map[Placement]bool) (Placement, error) {
+	letters := parse.Graphemes(word)
+	if len(letters) == 0 {
+		return Placement{}, ErrEmptyWord{}
+	}
+	for range placeAttempts {
+		start := grid.Point{X: r.IntN(g.Width), Y: r.IntN(g.Height)}
+		dir := dirs[r.IntN(len(dirs))]
+		placement := Placement{Word: word, Start: start, Direction: dir.Name()}
+		if placed[placement] {
+			continue
+		}
+		fits := true
+		for i, l := range letters {
+			cell, ok := g.Get(start.Add(dir.Scale(i)))
+			if !ok || (cell != "" && (!overlap || cell != l)) {
+				fits = false
+				break
+			}
+		}
+		if !fits {
+			continue
+		}
+		for i, l := range letters {
+			g.Set(start.Add(dir.Scale(i)), l)
+		}
+		return placement, nil
+	}
+	return Placement{}, ErrPlacement{Word: word}
+}
+
+// bruteCountWord counts a word by walking the line from every cell in every direction
+func bruteCountWord(g *grid.Grid[string], letters []string) int {
+	count := 0
+	for start := range g.Points() {
+		for _, dir := range grid.Directions() {
+			i := 0
+			for _, cell := range g.Line(start, dir) {
+				if i == len(letters) || cell != letters[i] {
+					break
+				}
+				i++
+			}
+			if i == len(letters) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// bruteCountBlocks counts block patterns by comparing every window of the grid with each rotation, a match of a
+// pattern covering the same cells as an earlier one is only counted once
+func bruteCountBlocks(g *grid.Grid[string], patterns []Sets) (int, error) {
+	count := 0
+	for _, pattern := range patterns {
+		pg, err := grid.FromRows(pattern)
+		if err != nil {
+			return 0, err
+		}
+		seen := make(map[string]bool)
+		for i := range 4 {
+			rotated := pg.Rotate(i)
+			for origin, window := range g.Windows(rotated.Width, rotated.Height) {
+				if !windowMatches(window, rotated) {
+					continue
+				}
+				cells := make([]grid.Point, 0, len(rotated.Cells))
+				for pt, c := range rotated.All() {
+					if c != c_space {
+						cells = append(cells, origin.Add(pt))
+					}
+				}
+				seen[fmt.Sprint(cells)] = true
+			}
+		}
+		count += len(seen)
+	}
+	return count, nil
+}
+
+// windowMatches checks if every letter of a pattern that isn't a wildcard is the same in a window
+func windowMatches(window *grid.Grid[string], pattern *grid.Grid[Cell]) bool {
+	for i, c := range pattern.Cells {
+		if c != c_space && window.Cells[i] != c.Letter {
+			return false
+		}
+	}
+	return true
+}
+
+// newGenerateCmd creates a new generate command
+func newGenerateCmd(h *common.Helpers) *cobra.Command {
+	opts := GenerateOptions{}
+	var wordsFile, file, keyFile string
+	var directions []string
+	generateCmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate a word search puzzle with an answer key",
+		Long: "Generate a word search puzzle by placing words in random spots and directions from a seed and filling the " +
+			"gaps from an alphabet. The answer key next to it has the placements and the expected CountWord totals of " +
+			"each word and CountBlocks total of --pattern (defaults to the X-MAS pattern)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if wordsFile != "" {
+				contents, err := os.ReadFile(wordsFile)
+				if err != nil {
+					return err
+				}
+				opts.Words, err = ParseWords(wordsFile, contents)
+				if err != nil {
+					return err
+				}
+			}
+			for _, name := range directions {
+				dir, err := grid.ParseDirection(name)
+				if err != nil {
+					return err
+				}
+				opts.Directions = append(opts.Directions, dir)
+			}
+			if keyFile == "" {
+				keyFile = file + keySuffix
+			}
+			return generate(h, opts, file, keyFile)
+		},
+	}
+	generateCmd.Flags().IntVar(&opts.Width, "width", 140, "width of the puzzle")
+	generateCmd.Flags().IntVar(&opts.Height, "height", 140, "height of the puzzle")
+	generateCmd.Flags().StringSliceVar(&opts.Words, "words", []string{"XMAS"}, "words to place")
+	generateCmd.Flags().StringVar(&wordsFile, "words-file", "", "file of words to place, a word per line (overrides --words)")
+	generateCmd.Flags().IntVar(&opts.Count, "count", 1, "number of times to place each word")
+	generateCmd.Flags().Uint64Var(&opts.Seed, "seed", 1, "seed of the random placements and filler, the same seed gives the same puzzle")
+	generateCmd.Flags().StringSliceVar(&directions, "directions", nil, "directions to place words in, e.g. E,SE (defaults to all 8)")
+	generateCmd.Flags().BoolVar(&opts.Overlap, "overlap", false, "let words share cells where their letters are the same")
+	generateCmd.Flags().StringVar(&opts.Alphabet, "alphabet", DefaultAlphabet, "letters to fill the gaps with")
+	generateCmd.Flags().StringVar(&file, "file", "", "file to write the puzzle to")
+	generateCmd.Flags().StringVar(&keyFile, "key-file", "", "file to write the answer key to as json (defaults to the puzzle file with "+keySuffix+")")
+	_ = generateCmd.MarkFlagRequired("file")
+	return generateCmd
+}
+
+// generate generates a puzzle and writes it and its answer key
+func generate(h *common.Helpers, opts GenerateOptions, file string, keyFile string) error {
+	h = h.With(common.DayAttr, day)
+	patterns, err := getPatterns(h)
+	if err != nil {
+		return err
+	}
+	opts.Patterns = patterns
+	generated, err := Generate(h, opts)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(file, []byte(generated.Puzzle), 0o644)
+	if err != nil {
+		h.Logger.Error("Error writing puzzle", common.ErrAttr, err)
+		return err
+	}
+	h.Logger.Info("Created puzzle", "file", file)
+	key, err := json.MarshalIndent(generated.Key, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(keyFile, append(key, '\n'), 0o644)
+	if err != nil {
+		h.Logger.Error("Error writing answer key", common.ErrAttr, err)
+		return err
+	}
+	h.Logger.Info("Created answer key", "file", keyFile)
+	return nil
+}
@@ -0,0 +1,150 @@
+package day4
+
+import (
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/grid"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGenerateProperties checks the puzzle engine against the answer keys of generated puzzles, for many seeds
+func TestGenerateProperties(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts GenerateOptions
+	}{
+		{
+			name: "xmas",
+			opts: GenerateOptions{Width: 20, Height: 15, Words: []string{"XMAS"}, Count: 5, Alphabet: DefaultAlphabet},
+		},
+		{
+			name: "overlap_palindromes",
+			opts: GenerateOptions{Width: 8, Height: 8, Words: []string{"MAM", "SAS", "XMAS"}, Count: 4, Overlap: true,
+				Alphabet: "XMAS"},
+		},
+		{
+			name: "east_only",
+			opts: GenerateOptions{Width: 30, Height: 4, Words: []string{"XMAS", "MAS"}, Count: 3,
+				Directions: []grid.Point{grid.E}, Alphabet: "MAS"},
+		},
+		{
+			name: "padded_pattern",
+			opts: GenerateOptions{Width: 6, Height: 5, Words: []string{"AA"}, Count: 2, Alphabet: "AB",
+				Patterns: []Sets{{{c_a, c_space}}, {ms, a, ms}}},
+		},
+		{
+			name: "unicode",
+			opts: GenerateOptions{Width: 10, Height: 10, Words: []string{"ΣΟΦΙΑ", "café"}, Count: 2, Overlap: true,
+				Alphabet: "ΣΟΦΙΑé"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for seed := range uint64(25) {
+				// Arrange
+				h := newTestHelpers(t)
+				opts := tc.opts
+				opts.Seed = seed
+				// Act
+				generated, err := Generate(h, opts)
+				assert.Nil(t, err)
+				p, err := GetPuzzle(h, &common.File{Name: t.Name(), Contents: []byte(generated.Puzzle)})
+				assert.Nil(t, err)
+				// Assert
+				assert.Len(t, generated.Key.Placements, len(opts.Words)*opts.Count)
+				placed := make(map[string]int)
+				for _, pl := range generated.Key.Placements {
+					placed[pl.Word]++
+					dir, err := grid.ParseDirection(pl.Direction)
+					assert.Nil(t, err)
+					if len(opts.Directions) > 0 {
+						assert.Contains(t, opts.Directions, dir)
+					}
+					for i, l := range parse.Graphemes(pl.Word) {
+						assert.Equal(t, l, p.Alphabet.Letter(p.Letters.At(pl.Start.Add(dir.Scale(i)))), "seed %d", seed)
+					}
+				}
+				for word, expected := range generated.Key.Words {
+					count, err := p.CountWord(h, word)
+					assert.Nil(t, err)
+					assert.Equal(t, expected, count, "seed %d word %s", seed, word)
+					assert.GreaterOrEqual(t, count, placed[word], "seed %d word %s", seed, word)
+				}
+				targets := opts.Patterns
+				if len(targets) == 0 {
+					targets = xmasTargets(t)
+				}
+				blocks, err := p.CountBlocks(h, targets, true)
+				assert.Nil(t, err)
+				assert.Equal(t, generated.Key.Blocks, blocks, "seed %d", seed)
+			}
+		})
+	}
+}
+
+// TestGenerate is a test for the Generate function
+func TestGenerate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		opts        GenerateOptions
+		expectedErr error
+	}{
+		{
+			name:        "no_cells",
+			opts:        GenerateOptions{Width: 0, Height: 3, Alphabet: DefaultAlphabet},
+			expectedErr: ErrGenerateSize{Width: 0, Height: 3},
+		},
+		{
+			name:        "no_alphabet",
+			opts:        GenerateOptions{Width: 3, Height: 3},
+			expectedErr: ErrEmptyAlphabet{},
+		},
+		{
+			name:        "too_long",
+			opts:        GenerateOptions{Width: 3, Height: 3, Words: []string{"XMAS"}, Count: 1, Alphabet: DefaultAlphabet},
+			expectedErr: ErrPlacement{Word: "XMAS"},
+		},
+		{
+			name:        "no_room_without_overlap",
+			opts:        GenerateOptions{Width: 4, Height: 1, Words: []string{"XMAS"}, Count: 2, Alphabet: DefaultAlphabet},
+			expectedErr: ErrPlacement{Word: "XMAS"},
+		},
+		{
+			name:        "empty_word",
+			opts:        GenerateOptions{Width: 3, Height: 3, Words: []string{""}, Count: 1, Alphabet: DefaultAlphabet},
+			expectedErr: ErrEmptyWord{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			h := newTestHelpers(t)
+			// Act
+			result, err := Generate(h, tc.opts)
+			// Assert
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+// TestGenerateDeterministic checks that a seed always gives the same puzzle, and different seeds different ones
+func TestGenerateDeterministic(t *testing.T) {
+	// Arrange
+	h := newTestHelpers(t)
+	opts := GenerateOptions{Width: 12, Height: 12, Words: []string{"XMAS"}, Count: 3, Seed: 42, Alphabet: DefaultAlphabet}
+	// Act
+	first, err := Generate(h, opts)
+	assert.Nil(t, err)
+	second, err := Generate(h, opts)
+	assert.Nil(t, err)
+	opts.Seed++
+	other, err := Generate(h, opts)
+	assert.Nil(t, err)
+	// Assert
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first.Puzzle, other.Puzzle)
+	assert.Len(t, parse.Lines("generated", []byte(first.Puzzle)), 12)
+}
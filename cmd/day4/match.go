@@ -0,0 +1,112 @@
+package day4
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/grid"
+)
+
+// Match is the struct for where a word or block pattern was found in the puzzle
+type Match struct {
+	// Pattern is the index of the block pattern that matched, always 0 for words
+	Pattern int `json:"pattern" yaml:"pattern"`
+	// Start is the first cell of a word, or the top left of a block
+	Start grid.Point `json:"start" yaml:"start"`
+	// Direction is the direction a word reads in, e.g. SE, empty for blocks
+	Direction string `json:"direction,omitempty" yaml:"direction,omitempty"`
+	// Rotation is the degrees clockwise a block pattern was rotated by, always 0 for words
+	Rotation int `json:"rotation" yaml:"rotation"`
+	// Reflected is true if a block pattern was mirrored left to right before it was rotated
+	Reflected bool `json:"reflected" yaml:"reflected"`
+	// Cells are the cells the match covers, wildcards aren't included
+	Cells []grid.Point `json:"cells" yaml:"cells"`
+}
+
+// ErrEmptyWord is an error that is returned when searching for an empty word
+type ErrEmptyWord struct{}
+
+// Error returns the error message
+func (e ErrEmptyWord) Error() string {
+	return "no word to find"
+}
+
+// Key returns what the match is tallied by, its direction for words and its orientation for blocks,
+// e.g. SE, rot90 or rot90-reflected
+func (m *Match) Key() string {
+	if m.Direction != "" {
+		return m.Direction
+	}
+	if m.Reflected {
+		return fmt.Sprintf("rot%d-reflected", m.Rotation)
+	}
+	return fmt.Sprintf("rot%d", m.Rotation)
+}
+
+// Tally returns the number of matches by key, see Match.Key
+func Tally(matches []*Match) map[string]int {
+	tally := make(map[string]int)
+	for _, m := range matches {
+		tally[m.Key()]++
+	}
+	return tally
+}
+
+// TallyKeys returns the keys of a tally in the order the matches were found, i.e. clockwise from N for words
+func TallyKeys(matches []*Match) []string {
+	keys := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, m := range matches {
+		if !seen[m.Key()] {
+			seen[m.Key()] = true
+			keys = append(keys, m.Key())
+		}
+	}
+	return keys
+}
+
+// FindWord returns every match of a word in the puzzle, by direction clockwise from N and then row by row,
+// it finds the same matches CountWord counts
+func (p *Puzzle) FindWord(h *common.Helpers, word string) ([]*Match, error) {
+	letters := p.Alphabet.Keys(word)
+	if len(letters) == 0 {
+		h.Logger.Error("Error finding word", common.ErrAttr, ErrEmptyWord{})
+		return nil, ErrEmptyWord{}
+	}
+	matches := make([]*Match, 0)
+	for start, dir := range scanWord(p.keys, letters) {
+		matches = append(matches, newWordMatch(start, dir, len(letters)))
+	}
+	slices.SortStableFunc(matches, compareWordMatches)
+	h.Logger.Debug("Found word", "word", word, "matches", len(matches))
+	return matches, nil
+}
+
+// FindBlocks returns every match of block patterns in the puzzle (use " " for wildcards), by pattern, orientation and
+// then row by row, it finds the same matches CountBlocksWithSymmetry counts
+func (p *Puzzle) FindBlocks(h *common.Helpers, targets []Sets, sym Symmetry) ([]*Match, error) {
+	all, err := getVariants(h, targets, sym)
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]*Match, 0)
+	for i, vs := range all {
+		sps := compileVariants(vs, p.Alphabet)
+		for origin, j := range scanVariants(p.keys, sps) {
+			cells := make([]grid.Point, 0, len(sps[j].offsets))
+			for _, o := range sps[j].offsets {
+				cells = append(cells, origin.Add(o))
+			}
+			matches = append(matches, &Match{
+				Pattern:   i,
+				Start:     origin,
+				Rotation:  vs[j].Rotation,
+				Reflected: vs[j].Reflected,
+				Cells:     cells,
+			})
+		}
+	}
+	h.Logger.Debug("Found blocks", "targets", len(targets), "matches", len(matches))
+	return matches, nil
+}
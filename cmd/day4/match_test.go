@@ -0,0 +1,114 @@
+package day4
+
+import (
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/grid"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// example is the first example from the puzzle
+const example = "MMMSXXMASM\nMSAMXMSMSA\nAMXSXMAAMM\nMSAMASMSMX\nXMASAMXAMM\nXXAMMXXAMA\nSMSMSASXSS\nSAXAMASAAA\nMAMMMXMMMM\nMXMXAXMASX\n"
+
+// newTestHelpers returns helpers for a test
+func newTestHelpers(t *testing.T) *common.Helpers {
+	s := test.NewTestStreams()
+	h, err := common.NewHelpers(s.Streams, test.NewTestViper(t), test.NewTestSlog(s.Streams))
+	assert.Nil(t, err)
+	return h
+}
+
+// newTestPuzzle returns helpers and a puzzle parsed from contents
+func newTestPuzzle(t *testing.T, contents string) (*common.Helpers, *Puzzle) {
+	h := newTestHelpers(t)
+	p, err := GetPuzzle(h, &common.File{Name: t.Name(), Contents: []byte(contents)})
+	assert.Nil(t, err)
+	return h, p
+}
+
+// xmasTargets returns the star 2 X-MAS pattern
+func xmasTargets(t *testing.T) []Sets {
+	targets, err := ParsePatterns("xmas", []byte(xmasPattern), DefaultWildcard)
+	assert.Nil(t, err)
+	return targets
+}
+
+// TestFindWord is a test for the FindWord function
+func TestFindWord(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		word          string
+		expected      []*Match
+		expectedTally map[string]int
+		expectedErr   error
+	}{
+		{
+			name:  "palindrome_both_ways",
+			input: "ABA\n...\n",
+			word:  "ABA",
+			expected: []*Match{
+				{Start: grid.Point{X: 0, Y: 0}, Direction: "E", Cells: []grid.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}},
+				{Start: grid.Point{X: 2, Y: 0}, Direction: "W", Cells: []grid.Point{{X: 2, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 0}}},
+			},
+			expectedTally: map[string]int{"E": 1, "W": 1},
+		},
+		{
+			name:  "diagonal",
+			input: "X...\n.M..\n..A.\n...S\n",
+			word:  "XMAS",
+			expected: []*Match{
+				{Start: grid.Point{X: 0, Y: 0}, Direction: "SE", Cells: []grid.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 3}}},
+			},
+			expectedTally: map[string]int{"SE": 1},
+		},
+		{
+			name:        "empty_word",
+			input:       "XMAS\n",
+			word:        "",
+			expectedErr: ErrEmptyWord{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			h, p := newTestPuzzle(t, tc.input)
+			// Act
+			result, err := p.FindWord(h, tc.word)
+			// Assert
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, result)
+			if tc.expectedErr == nil {
+				assert.Equal(t, tc.expectedTally, Tally(result))
+			}
+		})
+	}
+}
+
+// TestFindMatchesCount is a test that the Find functions find as many matches as the Count functions count
+func TestFindMatchesCount(t *testing.T) {
+	// Arrange
+	h, p := newTestPuzzle(t, example)
+	// Act
+	words, err := p.FindWord(h, "XMAS")
+	assert.Nil(t, err)
+	wordCount, err := p.CountWord(h, "XMAS")
+	assert.Nil(t, err)
+	blocks, err := p.FindBlocks(h, xmasTargets(t), Rotations)
+	assert.Nil(t, err)
+	blockCount, err := p.CountBlocks(h, xmasTargets(t), true)
+	assert.Nil(t, err)
+	// Assert
+	assert.Equal(t, 18, len(words))
+	assert.Equal(t, wordCount, len(words))
+	assert.Equal(t, []string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}, TallyKeys(words))
+	assert.Equal(t, map[string]int{"N": 2, "NE": 4, "E": 3, "SE": 1, "S": 1, "SW": 1, "W": 2, "NW": 4}, Tally(words))
+	assert.Equal(t, 9, len(blocks))
+	assert.Equal(t, blockCount, len(blocks))
+	for _, m := range blocks {
+		assert.Len(t, m.Cells, 5)
+		assert.Equal(t, m.Start.Add(grid.Point{X: 1, Y: 1}), m.Cells[2])
+	}
+}
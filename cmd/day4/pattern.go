@@ -0,0 +1,129 @@
+package day4
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/grid"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/parse"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// PatternKey is the viper key for the file of block patterns star 2 and match count
+	PatternKey = "day4-pattern"
+	// WildcardKey is the viper key for the character that matches any letter in a pattern file
+	WildcardKey = "day4-wildcard"
+	// IgnoreCaseKey is the viper key for matching letters case insensitively
+	IgnoreCaseKey = "day4-ignore-case"
+	// DefaultWildcard is the default wildcard of a pattern file
+	DefaultWildcard = "."
+	// xmasPattern is the star 2 pattern, an X of MAS
+	xmasPattern = "M.S\n.A.\nM.S\n"
+)
+
+// ErrWildcard is an error that is returned when the wildcard isn't a single character
+type ErrWildcard struct {
+	Wildcard string
+}
+
+// Error returns the error message
+func (e ErrWildcard) Error() string {
+	return fmt.Sprintf("wildcard %q must be a single character", e.Wildcard)
+}
+
+// AddFlags adds the day 4 flags, they are bound to viper so star 2 can read them too
+func (s *Solver) AddFlags(h *common.Helpers, fs *pflag.FlagSet) error {
+	fs.String("pattern", "", "file of block patterns, patterns are separated by empty lines (defaults to the X-MAS pattern)")
+	fs.String("wildcard", DefaultWildcard, "character that matches any letter in the pattern file")
+	fs.Bool("ignore-case", false, "match letters case insensitively, by their unicode case folding")
+	for key, name := range map[string]string{PatternKey: "pattern", WildcardKey: "wildcard", IgnoreCaseKey: "ignore-case"} {
+		err := h.Viper.BindPFlag(key, fs.Lookup(name))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConfigKeys returns the viper keys of the day 4 flags
+func (s *Solver) ConfigKeys() []string {
+	return []string{PatternKey, WildcardKey, IgnoreCaseKey}
+}
+
+// ValidateConfig returns every problem with the day 4 settings, a wildcard that isn't a single character or a pattern
+// file that can't be read
+func (s *Solver) ValidateConfig(h *common.Helpers) []error {
+	problems := make([]error, 0)
+	if w := h.Viper.GetString(WildcardKey); w != "" && len(parse.Graphemes(w)) != 1 {
+		problems = append(problems, ErrWildcard{Wildcard: w})
+	}
+	if file := h.Viper.GetString(PatternKey); file != "" {
+		if _, err := os.ReadFile(file); err != nil {
+			problems = append(problems, fmt.Errorf("%s can't be read: %w", PatternKey, err))
+		}
+	}
+	return problems
+}
+
+// ParsePatterns parses block patterns from text, a row per line of grapheme clusters and patterns separated by empty
+// lines, the wildcard (and a space) matches any letter, so a row of spaces is a row of wildcards and not a separator
+func ParsePatterns(resource string, contents []byte, wildcard string) ([]Sets, error) {
+	sections := parse.Sections(resource, contents)
+	if len(sections) == 0 {
+		return nil, parse.ErrParse{Resource: resource, Err: parse.ErrEmpty{}}
+	}
+	patterns := make([]Sets, 0, len(sections))
+	for _, section := range sections {
+		g, err := grid.FromClusterLines(resource, section, func(letter string) (Cell, error) {
+			if letter == wildcard {
+				return c_space, nil
+			}
+			return Cell{Letter: letter}, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, setsFromGrid(g))
+	}
+	return patterns, nil
+}
+
+// getWildcard returns the configured wildcard, in NFC like the letters of a pattern
+func getWildcard(h *common.Helpers) (string, error) {
+	w := h.Viper.GetString(WildcardKey)
+	if w == "" {
+		w = DefaultWildcard
+	}
+	clusters := parse.Graphemes(w)
+	if len(clusters) != 1 {
+		return "", ErrWildcard{Wildcard: w}
+	}
+	return clusters[0], nil
+}
+
+// getPatterns returns the block patterns from the configured pattern file, or the X-MAS pattern without one
+func getPatterns(h *common.Helpers) ([]Sets, error) {
+	path := h.Viper.GetString(PatternKey)
+	if path == "" {
+		return ParsePatterns("xmas", []byte(xmasPattern), DefaultWildcard)
+	}
+	wildcard, err := getWildcard(h)
+	if err != nil {
+		h.Logger.Error("Error getting wildcard", common.ErrAttr, err)
+		return nil, err
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		h.Logger.Error("Error reading patterns", common.ErrAttr, err)
+		return nil, err
+	}
+	patterns, err := ParsePatterns(path, contents, wildcard)
+	if err != nil {
+		h.Logger.Error("Error parsing patterns", common.ErrAttr, err)
+		return nil, err
+	}
+	h.Logger.Debug("Parsed patterns", "file", path, "patterns", len(patterns))
+	return patterns, nil
+}
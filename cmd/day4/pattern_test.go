@@ -0,0 +1,60 @@
+package day4
+
+import (
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParsePatterns is a test for the ParsePatterns function
+func TestParsePatterns(t *testing.T) {
+	testCases := []struct {
+		name        string
+		contents    string
+		wildcard    string
+		expected    []Sets
+		expectedErr error
+	}{
+		{
+			name:     "xmas",
+			contents: xmasPattern,
+			wildcard: ".",
+			expected: []Sets{{ms, a, ms}},
+		},
+		{
+			name:     "several_with_custom_wildcard",
+			contents: "\nM#S\n#A#\nM#S\n\n\nM.\n",
+			wildcard: "#",
+			expected: []Sets{{ms, a, ms}, {{c_m, Cell{Letter: "."}}}},
+		},
+		{
+			name:     "wildcard_row",
+			contents: "M S\n   \nM S\n\n.\n",
+			wildcard: ".",
+			expected: []Sets{{ms, {c_space, c_space, c_space}, ms}, {{c_space}}},
+		},
+		{
+			name:        "ragged",
+			contents:    "M.S\n.A.\n\nMS\nM\n",
+			wildcard:    ".",
+			expectedErr: parse.ErrParse{Resource: "r", Line: 5, Column: 2, Err: parse.ErrRagged{Expected: 2, Actual: 1}},
+		},
+		{
+			name:        "empty",
+			contents:    "\n\n",
+			wildcard:    ".",
+			expectedErr: parse.ErrParse{Resource: "r", Err: parse.ErrEmpty{}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			// Act
+			result, err := ParsePatterns("r", []byte(tc.contents), tc.wildcard)
+			// Assert
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
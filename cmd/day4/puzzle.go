@@ -2,40 +2,27 @@ package day4
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/mrlunchbox777/2024-advent-of-code/common"
-)
-
-const (
-	// Column is the column size type
-	Column = WrongSizeType("Column")
-	// Row is the row size type
-	Row = WrongSizeType("Row")
+	"github.com/mrlunchbox777/2024-advent-of-code/common/grid"
 )
 
 // Puzzle is the struct for a word search puzzle
 type Puzzle struct {
 	Raw string
-	Block
+	// Letters is the grid of letters, as symbols of the alphabet
+	Letters  *grid.Grid[Symbol]
+	Alphabet *Alphabet
+	// keys is the grid of the keys of the letters, words and blocks are scanned for in it in place rather than in
+	// copies of its lines
+	keys *grid.Grid[Symbol]
 }
 
-type Block struct {
-	Initialized bool
-	Rows        Sets
-	RRows       Sets
-	Cols        Sets
-	RCols       Sets
-	DDiag       Sets
-	RDDiag      Sets
-	ADiag       Sets
-	RADiag      Sets
-	Size        *Size
-}
-
-// Sets is a slice of sets
+// Sets is the rows of a block pattern
 type Sets []Set
 
-// Set is the struct for a row/col/diag in a word search puzzle
+// Set is a row of cells of a block pattern
 type Set []Cell
 
 // Cell is the struct for a cell in a word search puzzle
@@ -43,489 +30,113 @@ type Cell struct {
 	Letter string
 }
 
-// Size is the struct for the size of a block
-type Size struct {
-	X int
-	Y int
-}
-
-// Equals checks if two sizes are equal
-func (s *Size) Equals(o *Size) bool {
-	return s.X == o.X && s.Y == o.Y
-}
-
-// SetGroup is a slice of sets
-type SetGroup []Sets
-
-// BlockGroup is a slice of blocks
-type BlockGroup []*Block
-
-// SizeGroup is a slice of sizes
-type SizeGroup []*Size
-
-// SubBlocks is a slice of blocks by size
-type SubBlocks map[*Size]BlockGroup
-
-// WrongSizeType is an int that represents the type of wrong size error
-type WrongSizeType string
-
-// WrongSizeError is an error for when the sizes don't match
-type WrongSizeError struct {
-	Expected int
-	Actual   int
-	Type     WrongSizeType
-}
-
-// Error returns the error message
-func (e *WrongSizeError) Error() string {
-	return fmt.Sprintf("Wrong %s size. Expected %d, got %d", e.Type, e.Expected, e.Actual)
-}
-
 // GetPuzzle returns a new puzzle struct
 func GetPuzzle(h *common.Helpers, in *common.File) (*Puzzle, error) {
-	h.Logger.Debug("Getting puzzle")
 	return parseInput(h, in)
 }
 
 // parseInput parses the input file and returns the puzzle
 func parseInput(h *common.Helpers, in *common.File) (*Puzzle, error) {
-	h.Logger.Debug("Parsing input")
 	p := &Puzzle{
-		Raw: string(in.Contents),
-		Block: Block{
-			Initialized: false,
-		},
+		Raw:      string(in.Contents),
+		Alphabet: NewAlphabet(h.Viper.GetBool(IgnoreCaseKey)),
 	}
-	p.getRows(h)
-	return p, nil
-}
-
-// getBlock returns the block for a given Sets
-func getBlock(h *common.Helpers, sets Sets) (*Block, error) {
-	h.Logger.Debug("Getting block")
-	b := &Block{
-		Initialized: false,
-	}
-	err := b.initialize(h, sets)
+	g, err := grid.ParseClusters(in.Name, in.Contents, func(letter string) (Symbol, error) {
+		return p.Alphabet.Intern(letter), nil
+	})
 	if err != nil {
-		h.Logger.Error(fmt.Sprintf("Error initializing block: %s", err))
+		h.Logger.Error("Error getting grid", common.ErrAttr, err)
 		return nil, err
 	}
-	return b, nil
-}
-
-// getRows parses the rows, doesn't initialize the puzzle
-func (p *Puzzle) getRows(h *common.Helpers) {
-	h.Logger.Debug("Getting rows")
-	lines := h.GetLines(p.Raw)
-	for _, line := range lines {
-		var row Set
-		for _, letter := range line {
-			row = append(row, Cell{Letter: string(letter)})
-		}
-		p.Rows = append(p.Rows, row)
-	}
-}
-
-// getCols parses the columns
-func (p *Block) getCols(h *common.Helpers) {
-	h.Logger.Debug("Getting columns")
-	p.Cols = make([]Set, p.Size.X)
-	for _, r := range p.Rows {
-		for i, c := range r {
-			p.Cols[i] = append(p.Cols[i], c)
-		}
-	}
-	p.RCols = p.getRSets(h, p.Cols)
-}
-
-// getADiag parses the ascending diagonals, starting from the top left
-func (p *Block) getADiag(h *common.Helpers) {
-	if p.Size.Y != p.Size.X {
-		h.Logger.Debug("Can't get ascending diagonals")
-		return
-	}
-	h.Logger.Debug("Getting ascending diagonals")
-	p.ADiag = make([]Set, p.Size.Y*2-1)
-	for y := 0; y < p.Size.Y; y++ {
-		for x := 0; x < p.Size.X; x++ {
-			p.ADiag[y+x] = append(p.ADiag[y+x], p.Rows[y][x])
-		}
-	}
-	p.RADiag = p.getRSets(h, p.ADiag)
-}
-
-// getDDiag parses the descending diagonals, starting from the top right
-func (p *Block) getDDiag(h *common.Helpers) {
-	if p.Size.Y != p.Size.X {
-		h.Logger.Debug("Can't get descending diagonals")
-		return
-	}
-	h.Logger.Debug("Getting descending diagonals")
-	p.DDiag = make([]Set, p.Size.Y*2-1)
-	for y := 0; y < p.Size.Y; y++ {
-		xLen := p.Size.X - 1
-		for x := 0; x < p.Size.X; x++ {
-			p.DDiag[y+x] = append(p.DDiag[y+x], p.Rows[y][xLen-x])
-		}
-	}
-	p.RDDiag = p.getRSets(h, p.DDiag)
-}
-
-// getRSets the reverse set
-func (p *Block) getRSets(h *common.Helpers, s Sets) Sets {
-	h.Logger.Debug("Getting reverse set")
-	var rs Sets
-	for _, r := range s {
-		var rSet Set
-		for i := len(r) - 1; i >= 0; i-- {
-			rSet = append(rSet, r[i])
-		}
-		rs = append(rs, rSet)
-	}
-	return rs
+	h.Logger.Debug("Parsing input", "file", in.Name, "width", g.Width, "height", g.Height,
+		"letters", len(p.Alphabet.letters), "fold", p.Alphabet.Fold)
+	p.Letters = g
+	p.keys = p.Alphabet.KeyGrid(g)
+	return p, nil
 }
 
-// initialize initializes the block
-func (b *Block) initialize(h *common.Helpers, rows Sets) error {
-	if b.Initialized {
-		h.Logger.Debug("Block already initialized")
-		return nil
-	}
-	if len(rows) == 0 {
-		h.Logger.Error("No rows to initialize")
-		return fmt.Errorf("No rows to initialize")
-	}
-	h.Logger.Debug(fmt.Sprintf("Initializing block: %d x %d", len(rows), len(rows[0])))
-	b.Rows = rows
-	b.Size = &Size{
-		X: len(b.Rows[0]),
-		Y: len(b.Rows),
+// setsFromGrid returns the rows of a grid
+func setsFromGrid(g *grid.Grid[Cell]) Sets {
+	sets := make(Sets, 0, g.Height)
+	for _, row := range g.Rows() {
+		sets = append(sets, Set(row))
 	}
-	b.RRows = b.getRSets(h, b.Rows)
-	b.getCols(h)
-	b.getADiag(h)
-	b.getDDiag(h)
-	b.Initialized = true
-	return nil
+	return sets
 }
 
-// CountWord returns the number of times a word appears in the puzzle
+// CountWord returns the number of times a word appears in the puzzle, in any of the 8 directions, the word is
+// compared letter by letter as grapheme clusters
 func (p *Puzzle) CountWord(h *common.Helpers, word string) (int, error) {
-	return p.countWordInBlock(h, word)
-}
-
-// countWordInSets returns the number of times a word appears in a set of cells
-func (p *Block) countWordInSets(sets Sets, word string) int {
-	count := 0
-	for _, s := range sets {
-		count += p.countWordInSet(s, word)
+	letters := p.Alphabet.Keys(word)
+	if len(letters) == 0 {
+		h.Logger.Error("Error counting word", common.ErrAttr, ErrEmptyWord{})
+		return 0, ErrEmptyWord{}
 	}
-	return count
-}
-
-// countWordInSet returns the number of times a word appears in a set of cells
-func (p *Block) countWordInSet(set Set, word string) int {
 	count := 0
-	for i := 0; i < len(set)-len(word)+1; i++ {
-		if p.checkSegment(set[i:i+len(word)], word) {
-			count++
-		}
-	}
-	return count
-}
-
-// checkSegment checks if a segment contains a word
-func (p *Block) checkSegment(cells []Cell, word string) bool {
-	for i, c := range cells {
-		if c.Letter != string(word[i]) {
-			return false
-		}
+	for range scanWord(p.keys, letters) {
+		count++
 	}
-	return true
-}
-
-// countWordInBlock returns the number of times a word appears in a block
-func (b *Block) countWordInBlock(h *common.Helpers, word string) (int, error) {
-	h.Logger.Debug("Counting word in block")
-	if !b.Initialized {
-		err := b.initialize(h, b.Rows)
-		if err != nil {
-			h.Logger.Error(fmt.Sprintf("Error initializing block: %s", err))
-			return 0, err
-		}
-	}
-	count := 0
-	count += b.countWordInSets(b.Rows, word)
-	count += b.countWordInSets(b.RRows, word)
-	count += b.countWordInSets(b.Cols, word)
-	count += b.countWordInSets(b.RCols, word)
-	count += b.countWordInSets(b.DDiag, word)
-	count += b.countWordInSets(b.RDDiag, word)
-	count += b.countWordInSets(b.ADiag, word)
-	count += b.countWordInSets(b.RADiag, word)
+	h.Logger.Debug("Counted word", "word", word, "count", count)
 	return count, nil
 }
 
-// getBlocksFromBlock returns the subblocks from a block based on a target size
-func (b *Block) getBlocksFromBlock(h *common.Helpers, target *Size) (BlockGroup, error) {
-	h.Logger.Debug("Getting blocks from block")
-	h.Logger.Debug(fmt.Sprintf("Target: %d x %d", target.X, target.Y))
-	h.Logger.Debug(fmt.Sprintf("Block: %d x %d", b.Size.X, b.Size.Y))
-	blocks := make([]*Block, 0)
-	// get the first row of target sized blocks from the source
-	for y := 0; y < b.Size.Y-target.Y; y++ {
-		for x := 0; x < b.Size.X-target.X; x++ {
-			block := &Block{}
-			// get the subset of rows
-			for i := 0; i < target.Y; i++ {
-				row := make(Set, 0)
-				// only get the subset of columns
-				row = append(row, b.Rows[y+i][x:x+target.X]...)
-				block.Rows = append(block.Rows, row)
-			}
-			h.Logger.Debug(fmt.Sprintf("Block: %d x %d", target.X, target.Y))
-			err := block.initialize(h, block.Rows)
-			if err != nil {
-				h.Logger.Error(fmt.Sprintf("Error initializing block: %s", err))
-				return nil, err
-			}
-			blocks = append(blocks, block)
-		}
-	}
-	return blocks, nil
-}
-
-// doBlocksMatch checks if two blocks match, use " " for wildcards
-func (b *Block) doBlocksMatch(h *common.Helpers, target *Block) (bool, error) {
-	h.Logger.Debug("Checking if blocks match")
-	if b.Size.Y != target.Size.Y {
-		return false, &WrongSizeError{
-			Expected: b.Size.Y,
-			Actual:   target.Size.Y,
-			Type:     Row,
-		}
-	}
-	if b.Size.X != target.Size.X {
-		return false, &WrongSizeError{
-			Expected: b.Size.X,
-			Actual:   target.Size.X,
-			Type:     Column,
-		}
-	}
-	// every cell must match
-	for y := 0; y < b.Size.Y; y++ {
-		for x := 0; x < b.Size.X; x++ {
-			// skip wildcards
-			if target.Rows[y][x].Letter == " " {
-				continue
-			}
-			if b.Rows[y][x].Letter != target.Rows[y][x].Letter {
-				return false, nil
-			}
-		}
-	}
-	return true, nil
-}
-
-// rotate90 rotates the block 90 degrees
-func (b *Block) rotate90(h *common.Helpers, init bool) (*Block, error) {
-	h.Logger.Debug("Rotating block")
-	if b.Size.Y == 0 {
-		h.Logger.Error("No rows to rotate")
-		return nil, fmt.Errorf("No rows to rotate")
-	}
-	block := &Block{}
-	block.Rows = make(Sets, b.Size.X)
-	for x := 0; x < b.Size.X; x++ {
-		block.Rows[x] = make(Set, b.Size.Y)
-		for y := 0; y < b.Size.Y; y++ {
-			block.Rows[x][y] = b.Rows[b.Size.Y-y-1][x]
-		}
-	}
-	if init {
-		err := block.initialize(h, block.Rows)
-		if err != nil {
-			h.Logger.Error(fmt.Sprintf("Error initializing block: %s", err))
-			return nil, err
-		}
-	} else {
-		block.Size = &Size{
-			X: b.Size.Y,
-			Y: b.Size.X,
-		}
-	}
-	return block, nil
-}
-
-// rotate90x rotates the block 90 degrees
-func (b *Block) rotate90x(h *common.Helpers, count int) (*Block, error) {
-	h.Logger.Debug("Rotating block")
-	block := b
-	var err error
-	for i := 0; i < count; i++ {
-		block, err = block.rotate90(h, false)
-		if err != nil {
-			h.Logger.Error(fmt.Sprintf("Error rotating block: %s", err))
-			return nil, err
-		}
+// getVariants returns the distinct orientations of every block pattern in a symmetry
+func getVariants(h *common.Helpers, targets []Sets, sym Symmetry) ([][]Variant, error) {
+	if len(targets) == 0 {
+		h.Logger.Error("No targets to count")
+		return nil, fmt.Errorf("No targets to count")
 	}
-	err = block.initialize(h, block.Rows)
-	return block, err
-}
-
-// doBlocksMatchAny checks if two blocks match in any orientation (rotated and checked 3 times), use " " for wildcards
-func (b *Block) doBlocksMatchAny(h *common.Helpers, targets BlockGroup) (int, error) {
-	h.Logger.Debug("Checking if blocks match")
-	count := 0
+	all := make([][]Variant, 0, len(targets))
 	for _, target := range targets {
-		match, err := b.doBlocksMatch(h, target)
-		if err != nil {
-			h.Logger.Error(fmt.Sprintf("Error checking if blocks match: %s", err))
-			return 0, err
+		h.Logger.Debug("Counting target", "rows", len(target))
+		if len(target) == 0 {
+			h.Logger.Error("No target size Y")
+			return nil, fmt.Errorf("No target size Y")
 		}
-		if match {
-			count++
+		if len(target[0]) == 0 {
+			h.Logger.Error("No target size X")
+			return nil, fmt.Errorf("No target size X")
 		}
-	}
-	return count, nil
-}
-
-// getBlocks returns blocks for a slice of Sets
-func (s SetGroup) getBlocks(h *common.Helpers) (BlockGroup, error) {
-	h.Logger.Debug("Getting blocks")
-	blocks := make(BlockGroup, 0)
-	for _, set := range s {
-		block, err := getBlock(h, set)
+		g, err := grid.FromRows(target)
 		if err != nil {
-			h.Logger.Error(fmt.Sprintf("Error getting block: %s", err))
+			h.Logger.Error("Error getting grid", common.ErrAttr, err)
 			return nil, err
 		}
-		blocks = append(blocks, block)
-	}
-	return blocks, nil
-}
-
-// rotateBlocks gets all 4 rotations of a slice of blocks
-func (b BlockGroup) rotateBlocks(h *common.Helpers) (BlockGroup, error) {
-	h.Logger.Debug("Rotating blocks")
-	rotatedBlocks := make(BlockGroup, 0)
-	for _, block := range b {
-		for i := 0; i < 4; i++ {
-			newBlock, err := block.rotate90x(h, i)
-			if err != nil {
-				h.Logger.Error(fmt.Sprintf("Error rotating block: %s", err))
-				return nil, err
-			}
-			rotatedBlocks = append(rotatedBlocks, newBlock)
-		}
-	}
-	return rotatedBlocks, nil
-}
-
-// getSizesFromBlocks returns the sizes of a slice of blocks
-func (b BlockGroup) getSizesFromBlocks() []*Size {
-	sizes := make([]*Size, 0)
-	for _, block := range b {
-		alreadyExists := false
-		for _, size := range sizes {
-			if size.Equals(block.Size) {
-				alreadyExists = true
-				break
-			}
-		}
-		if !alreadyExists {
-			sizes = append(sizes, block.Size)
-		}
-	}
-	return sizes
-}
-
-// getSubBlocksFromSizes returns the subblocks from a slice of sizes
-func (b *Block) getSubBlocksFromSizes(h *common.Helpers, sizes SizeGroup) (SubBlocks, error) {
-	h.Logger.Debug("Getting subblocks from sizes")
-	subBlocks := make(SubBlocks, 0)
-	for _, size := range sizes {
-		h.Logger.Debug(fmt.Sprintf("Target size: %d x %d", size.X, size.Y))
-		subBlock, err := b.getBlocksFromBlock(h, size)
+		vs, err := variants(g, sym)
 		if err != nil {
-			h.Logger.Error(fmt.Sprintf("Error getting blocks from block: %s", err))
+			h.Logger.Error("Error getting variants", common.ErrAttr, err)
 			return nil, err
 		}
-		subBlocks[size] = subBlock
+		all = append(all, vs)
 	}
-	return subBlocks, nil
-}
-
-// countBlockInBlockPerSubBlock returns the number of times a Block appears in a block (use " " for wildcards)
-func (b *Block) countBlockInBlockPerSubBlock(h *common.Helpers, subBlocks SubBlocks, targetBlocks BlockGroup) (int, error) {
-	h.Logger.Debug("Counting block in block per subblock")
-	count := 0
-	for _, subBlock := range subBlocks {
-		for _, block := range subBlock {
-			match, err := block.doBlocksMatchAny(h, targetBlocks)
-			if err != nil {
-				h.Logger.Error(fmt.Sprintf("Error checking if blocks match: %s", err))
-				return 0, err
-			}
-			count += match
-		}
+	// counting the variants allocates, so only do it for debug logs
+	if h.DebugEnabled() {
+		h.Logger.Debug("Oriented targets", "targets", len(targets), "symmetry", sym,
+			"variants", len(slices.Concat(all...)))
 	}
-	return count, nil
+	return all, nil
 }
 
-// countBlockInBlock returns the number of times a Block appears in a block (use " " for wildcards)
-func (b *Block) countBlockInBlock(h *common.Helpers, targets SetGroup, rotate bool) (int, error) {
-	h.Logger.Debug("Counting block in block")
-	if !b.Initialized {
-		err := b.initialize(h, b.Rows)
-		if err != nil {
-			h.Logger.Error(fmt.Sprintf("Error initializing block: %s", err))
-			return 0, err
-		}
-	}
-
-	targetBlocks, err := targets.getBlocks(h)
-	if err != nil {
-		h.Logger.Error(fmt.Sprintf("Error getting blocks: %s", err))
-		return 0, err
-	}
-
-	if rotate {
-		targetBlocks, err = targetBlocks.rotateBlocks(h)
-		if err != nil {
-			h.Logger.Error(fmt.Sprintf("Error rotating blocks: %s", err))
-			return 0, err
-		}
-	}
-
-	targetSizes := targetBlocks.getSizesFromBlocks()
+// CountBlocks returns the number of times the block patterns appear in the puzzle, in any of their rotations if
+// rotate is true
+func (p *Puzzle) CountBlocks(h *common.Helpers, targets []Sets, rotate bool) (int, error) {
+	return p.CountBlocksWithSymmetry(h, targets, symmetryOf(rotate))
+}
 
-	subBlocks, err := b.getSubBlocksFromSizes(h, targetSizes)
+// CountBlocksWithSymmetry returns the number of times the block patterns appear in the puzzle (use " " for
+// wildcards), in any orientation of a symmetry, matches of different orientations that cover the same cells are only
+// counted once
+func (p *Puzzle) CountBlocksWithSymmetry(h *common.Helpers, targets []Sets, sym Symmetry) (int, error) {
+	all, err := getVariants(h, targets, sym)
 	if err != nil {
-		h.Logger.Error(fmt.Sprintf("Error getting subblocks from sizes: %s", err))
 		return 0, err
 	}
-
-	return b.countBlockInBlockPerSubBlock(h, subBlocks, targetBlocks)
-}
-
-// CountBlocks returns the number of times a Block appears in the puzzle
-func (p *Puzzle) CountBlocks(h *common.Helpers, targets []Sets, rotate bool) (int, error) {
-	if len(targets) == 0 {
-		h.Logger.Error("No targets to count")
-		return 0, fmt.Errorf("No targets to count")
-	}
-	for _, target := range targets {
-		h.Logger.Debug(fmt.Sprintf("Target: %d x %d", len(target), len(target[0])))
-		if len(targets[0]) == 0 {
-			h.Logger.Error("No target size Y")
-			return 0, fmt.Errorf("No target size Y")
-		}
-		if len(targets[0][0]) == 0 {
-			h.Logger.Error("No target size X")
-			return 0, fmt.Errorf("No target size X")
+	count := 0
+	for _, vs := range all {
+		for range scanVariants(p.keys, compileVariants(vs, p.Alphabet)) {
+			count++
 		}
 	}
-	return p.countBlockInBlock(h, targets, true)
+	h.Logger.Debug("Counted blocks", "targets", len(targets), "count", count)
+	return count, nil
 }
@@ -4,53 +4,75 @@ import (
 	"testing"
 
 	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/grid"
 	"github.com/mrlunchbox777/2024-advent-of-code/common/test"
-	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
 var (
-	s_a       = Set{Cell{Letter: "A"}}
-	s_m       = Set{Cell{Letter: "M"}}
-	s_s       = Set{Cell{Letter: "S"}}
-	s_space   = Set{Cell{Letter: " "}}
-	s2_aspace = Set{Cell{Letter: "A"}, Cell{Letter: " "}}
-	s2_spacea = Set{Cell{Letter: " "}, Cell{Letter: "A"}}
-	s2_mspace = Set{Cell{Letter: "M"}, Cell{Letter: " "}}
-	s2_spacem = Set{Cell{Letter: " "}, Cell{Letter: "M"}}
-	s2_sspace = Set{Cell{Letter: "S"}, Cell{Letter: " "}}
-	s2_spaces = Set{Cell{Letter: " "}, Cell{Letter: "S"}}
+	c_a = Cell{Letter: "A"}
+	c_m = Cell{Letter: "M"}
+	c_s = Cell{Letter: "S"}
+	a   = Set{c_space, c_a, c_space}
+	ms  = Set{c_m, c_space, c_s}
+	mm  = Set{c_m, c_space, c_m}
+	s_a = Set{c_a}
+	// s2_mspace and the like are rows of two cells, e.g. M and a wildcard
+	s2_mspace = Set{c_m, c_space}
+	s2_aspace = Set{c_a, c_space}
+	s2_spacem = Set{c_space, c_m}
 )
 
-// TestRotate90 is a test for the rotate90 function
-func TestRotate90(t *testing.T) {
+// TestRotatePattern is a test for rotating block patterns on the grid, as star 2 orients them
+func TestRotatePattern(t *testing.T) {
+	sm := Set{c_s, c_space, c_m}
+	ss := Set{c_s, c_space, c_s}
 	testCases := []struct {
-		name      string
-		input     *Block
-		expected  *Block
-		diagsInit bool
+		name     string
+		input    Sets
+		times    int
+		expected Sets
+	}{
+		{name: "rotate_1x3_once", input: Sets{ms}, times: 1, expected: Sets{{c_m}, {c_space}, {c_s}}},
+		{name: "rotate_1x3_twice", input: Sets{ms}, times: 2, expected: Sets{sm}},
+		{name: "rotate_1x3_three_times", input: Sets{ms}, times: 3, expected: Sets{{c_s}, {c_space}, {c_m}}},
+		{name: "rotate_3x3_once", input: Sets{ms, a, ms}, times: 1, expected: Sets{mm, a, ss}},
+		{name: "rotate_3x3_twice", input: Sets{ms, a, ms}, times: 2, expected: Sets{sm, a, sm}},
+		{name: "rotate_3x3_three_times", input: Sets{ms, a, ms}, times: 3, expected: Sets{ss, a, mm}},
+		{name: "rotate_3x3_four_times", input: Sets{ms, a, ms}, times: 4, expected: Sets{ms, a, ms}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			g, err := grid.FromRows(tc.input)
+			assert.Nil(t, err)
+			// Act
+			result := setsFromGrid(g.Rotate(tc.times))
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+// TestCountEdges is a test for matches on the edges of the puzzle, a trailing newline mustn't add an empty row and
+// blocks in the last row and column are counted
+func TestCountEdges(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		blocks   bool
+		expected int
 	}{
 		{
-			name: "rotate90_1x3",
-			input: &Block{
-				Rows: Sets{ms},
-			},
-			expected: &Block{
-				Rows: Sets{
-					{Cell{Letter: "M"}},
-					{Cell{Letter: " "}},
-					{Cell{Letter: "S"}},
-				},
-			},
-			diagsInit: false,
+			name:     "trailing_newline_diagonal",
+			input:    "X...\n.M..\n..A.\n...S\n",
+			expected: 1,
 		},
 		{
-			name: "rotate90_3x3",
-			input: &Block{
-				Rows: Sets{ms, a, ms}},
-			expected: &Block{
-				Rows: Sets{mm, a, ss}},
-			diagsInit: true,
+			name:     "block_in_last_row_and_column",
+			input:    "....\n.M.S\n..A.\n.M.S",
+			blocks:   true,
+			expected: 1,
 		},
 	}
 	for _, tc := range testCases {
@@ -58,133 +80,79 @@ func TestRotate90(t *testing.T) {
 			// Arrange
 			s := test.NewTestStreams()
 			l := test.NewTestSlog(s.Streams)
-			v := viper.New()
+			v := test.NewTestViper(t)
 			h, err := common.NewHelpers(s.Streams, v, l)
 			if err != nil {
 				l.Error(err.Error())
 				t.Log(err)
 				t.Fail()
 			}
-			// Act
-			result, err := tc.input.rotate90(h, false)
-			// Assert
+			p, err := GetPuzzle(h, &common.File{Name: tc.name, Contents: []byte(tc.input)})
 			assert.Nil(t, err)
-			assert.Equal(t, tc.expected, result)
 			// Act
-			err = result.initialize(h, result.Rows)
-			// Assert
-			assert.Nil(t, err)
-			assert.Equal(t, tc.expected.Rows, result.Rows)
-			assert.Empty(t, tc.input.RRows)
-			assert.NotEqual(t, tc.input.RRows, result.RRows)
-			assert.Empty(t, tc.input.Cols)
-			assert.NotEqual(t, tc.input.Cols, result.Cols)
-			assert.Empty(t, tc.input.RCols)
-			assert.NotEqual(t, tc.input.RCols, result.RCols)
-			assert.Empty(t, tc.input.ADiag)
-			assert.Empty(t, tc.input.RADiag)
-			assert.Empty(t, tc.input.DDiag)
-			assert.Empty(t, tc.input.RDDiag)
-			if tc.diagsInit {
-				assert.NotEmpty(t, result.ADiag)
-				assert.NotEmpty(t, result.RADiag)
-				assert.NotEmpty(t, result.DDiag)
-				assert.NotEmpty(t, result.RDDiag)
+			var result int
+			if tc.blocks {
+				result, err = p.CountBlocks(h, []Sets{{ms, a, ms}}, true)
 			} else {
-				assert.Empty(t, result.ADiag)
-				assert.Empty(t, result.RADiag)
-				assert.Empty(t, result.DDiag)
-				assert.Empty(t, result.RDDiag)
+				result, err = p.CountWord(h, "XMAS")
 			}
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, result)
 		})
 	}
 }
 
-// TestRotate90x is a test for the rotate90x function
-func TestRotate90x(t *testing.T) {
-	testBlock_3x1 := &Block{
-		Rows: Sets{ms},
-	}
-	testBlock_3x3 := &Block{
-		Rows: Sets{
-			ms,
-			a,
-			ms,
-		},
-	}
+// TestCountWord is a test for the CountWord function, including diagonals of rectangular puzzles
+func TestCountWord(t *testing.T) {
 	testCases := []struct {
-		name     string
-		input    *Block
-		times    int
-		expected *Block
+		name        string
+		input       string
+		word        string
+		expected    int
+		expectedErr error
 	}{
 		{
-			name:  "rotate90x_3x1_once",
-			input: testBlock_3x1,
-			times: 1,
-			expected: &Block{
-				Rows: Sets{
-					{Cell{Letter: "M"}},
-					{Cell{Letter: " "}},
-					{Cell{Letter: "S"}},
-				},
-			},
+			name:     "square",
+			input:    "XMAS\nM..A\nA..M\nSAMX\n",
+			word:     "XMAS",
+			expected: 4,
+		},
+		{
+			name:     "wide",
+			input:    "..X...\n.SAMX.\n.A..A.\nXMAS.S\n.X....\n",
+			word:     "XMAS",
+			expected: 4,
 		},
 		{
-			name:  "rotate90x_3x1_twice",
-			input: testBlock_3x1,
-			times: 2,
-			expected: &Block{
-				Rows: Sets{sm},
-			},
+			name:     "tall",
+			input:    "X...\n.M..\n..A.\nXMAS\n..A.\n.M..\nX...\n",
+			word:     "XMAS",
+			expected: 3,
 		},
 		{
-			name:  "rotate90x_3x1_three_times",
-			input: testBlock_3x1,
-			times: 3,
-			expected: &Block{
-				Rows: Sets{
-					{Cell{Letter: "S"}},
-					{Cell{Letter: " "}},
-					{Cell{Letter: "M"}},
-				},
-			},
+			name:     "wide_diagonals_only",
+			input:    "X..S..\n.MA...\n.MA...\nX..S..\n",
+			word:     "XMAS",
+			expected: 2,
 		},
 		{
-			name:  "rotate90x_3x3_once",
-			input: testBlock_3x3,
-			times: 1,
-			expected: &Block{
-				Rows: Sets{
-					mm,
-					a,
-					ss,
-				},
-			},
+			name:     "tall_diagonals_only",
+			input:    "...S\n..A.\n.M..\nX...\n.M..\n..A.\n...S\n",
+			word:     "XMAS",
+			expected: 2,
 		},
 		{
-			name:  "rotate90x_3x3_twice",
-			input: testBlock_3x3,
-			times: 2,
-			expected: &Block{
-				Rows: Sets{
-					sm,
-					a,
-					sm,
-				},
-			},
+			name:     "single_row",
+			input:    "XMASAMX\n",
+			word:     "XMAS",
+			expected: 2,
 		},
 		{
-			name:  "rotate90x_3x3_three_times",
-			input: testBlock_3x3,
-			times: 3,
-			expected: &Block{
-				Rows: Sets{
-					ss,
-					a,
-					mm,
-				},
-			},
+			name:        "empty_word",
+			input:       "XMAS\n",
+			word:        "",
+			expectedErr: ErrEmptyWord{},
 		},
 	}
 	for _, tc := range testCases {
@@ -192,53 +160,20 @@ func TestRotate90x(t *testing.T) {
 			// Arrange
 			s := test.NewTestStreams()
 			l := test.NewTestSlog(s.Streams)
-			v := viper.New()
+			v := test.NewTestViper(t)
 			h, err := common.NewHelpers(s.Streams, v, l)
 			if err != nil {
 				l.Error(err.Error())
 				t.Log(err)
 				t.Fail()
 			}
+			p, err := GetPuzzle(h, &common.File{Name: tc.name, Contents: []byte(tc.input)})
+			assert.Nil(t, err)
 			// Act
-			result, err := tc.input.rotate90x(h, tc.times)
+			result, err := p.CountWord(h, tc.word)
 			// Assert
-			assert.Nil(t, err)
-			assert.Equal(t, tc.expected.Rows, result.Rows)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, result)
 		})
 	}
 }
-
-// TestGetSubBlockFromSizes is a test for the getSubBlockFromSizes function
-// func TestGetSubBlockFromSizes(t *testing.T) {
-// 	testBlock_3x3 := &Block{
-// 		Rows: Sets{
-// 			ms,
-// 			a,
-// 			ms,
-// 		},
-// 	}
-// 	testCases := []struct {
-// 		name     string
-// 		input    SizeGroup
-// 		expected BlockGroup
-// 	}{
-// 		{
-// 			name: "getSubBlockFromSizes_1x1",
-// 			input: SizeGroup{
-// 				Rows: 1,
-// 				Cols: 1,
-// 			},
-// 			expected: BlockGroup{
-// 				s_m, s_space, s_s,
-// 				s_space, s_a, s_space,
-// 				s_m, s_space, s_s,
-// 			},
-// 		},
-// 		{
-// 			name: "getSubBlockFromSizes_2x2",
-// 			input: SizeGroup{
-// 				Rows: 2,
-// 				Cols: 2,
-// 			},
-// 			expected: BlockGroup{
-// 				{}
@@ -0,0 +1,275 @@
+package day4
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/grid"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// ANSIRenderer draws the grid with ANSI colours for a terminal
+	ANSIRenderer = Renderer("ansi")
+	// HTMLRenderer draws the grid as a standalone HTML page
+	HTMLRenderer = Renderer("html")
+	// SVGRenderer draws the grid as an SVG image with a stroke through each match
+	SVGRenderer = Renderer("svg")
+	// dimLetter replaces the letters no match covers when dimming, like the puzzle's own illustration
+	dimLetter = "."
+	// svgCell is the width and height of a cell of an SVG image
+	svgCell = 24
+	// ansiReset resets the colour
+	ansiReset = "\x1b[0m"
+	// ansiFaint makes the text faint
+	ansiFaint = "\x1b[2m"
+)
+
+// ansiColours are the colours of the matches in a terminal, bold red, green, yellow, blue, magenta and cyan
+var ansiColours = []string{"\x1b[1;31m", "\x1b[1;32m", "\x1b[1;33m", "\x1b[1;34m", "\x1b[1;35m", "\x1b[1;36m"}
+
+// webColours are the colours of the matches in HTML and SVG, in the same order as ansiColours
+var webColours = []string{"#d62728", "#2ca02c", "#bcbd22", "#1f77b4", "#9467bd", "#17becf"}
+
+// Renderer is the format a picture of the matches is drawn in
+type Renderer string
+
+// Renderers returns every renderer
+func Renderers() []Renderer {
+	return []Renderer{ANSIRenderer, HTMLRenderer, SVGRenderer}
+}
+
+// ErrUnknownRenderer is an error that is returned for a renderer that doesn't exist
+type ErrUnknownRenderer struct {
+	Renderer string
+}
+
+// Error returns the error message
+func (e ErrUnknownRenderer) Error() string {
+	return fmt.Sprintf("unknown renderer %q, expected one of %v", e.Renderer, Renderers())
+}
+
+// ParseRenderer returns the renderer with a name
+func ParseRenderer(name string) (Renderer, error) {
+	for _, r := range Renderers() {
+		if string(r) == name {
+			return r, nil
+		}
+	}
+	return "", ErrUnknownRenderer{Renderer: name}
+}
+
+// Picture is the struct for the letters of a puzzle with the matches found in it, ready to draw
+type Picture struct {
+	// Letters are the letters of the puzzle as they were written
+	Letters *grid.Grid[string]
+	Matches []*Match
+	// Dim replaces the letters no match covers with dots
+	Dim bool
+	// Color colours the ansi renderer, without it the letters are plain text, see common.IsTerminal
+	Color bool
+	// owners is the index of the first match covering each cell, -1 if none does
+	owners *grid.Grid[int]
+}
+
+// NewPicture creates a picture of the matches found in a puzzle, each cell is coloured by the first match covering it
+func NewPicture(p *Puzzle, matches []*Match, dim bool) *Picture {
+	pic := &Picture{
+		Letters: grid.Map(p.Letters, p.Alphabet.Letter),
+		Matches: matches,
+		Dim:     dim,
+		owners: grid.Map(p.Letters, func(Symbol) int {
+			return -1
+		}),
+	}
+	for i, m := range matches {
+		for _, c := range m.Cells {
+			if owner, ok := pic.owners.Get(c); ok && owner < 0 {
+				pic.owners.Set(c, i)
+			}
+		}
+	}
+	return pic
+}
+
+// Render draws the picture to a writer with a renderer
+func (pic *Picture) Render(w io.Writer, r Renderer) error {
+	var b strings.Builder
+	switch r {
+	case ANSIRenderer:
+		pic.renderANSI(&b)
+	case HTMLRenderer:
+		pic.renderHTML(&b)
+	case SVGRenderer:
+		pic.renderSVG(&b)
+	default:
+		return ErrUnknownRenderer{Renderer: string(r)}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// cell returns the letter to draw at a point and the index of the match covering it, -1 if none does
+func (pic *Picture) cell(pt grid.Point) (string, int) {
+	owner := pic.owners.At(pt)
+	if owner < 0 && pic.Dim {
+		return dimLetter, owner
+	}
+	return pic.Letters.At(pt), owner
+}
+
+// renderANSI draws a line per row, matched letters are bold in the colour of their match and dots are faint, or
+// plain text without Color
+func (pic *Picture) renderANSI(b *strings.Builder) {
+	for y := 0; y < pic.Letters.Height; y++ {
+		for x := 0; x < pic.Letters.Width; x++ {
+			letter, owner := pic.cell(grid.Point{X: x, Y: y})
+			switch {
+			case !pic.Color:
+				b.WriteString(letter)
+			case owner >= 0:
+				fmt.Fprintf(b, "%s%s%s", ansiColours[owner%len(ansiColours)], letter, ansiReset)
+			case pic.Dim:
+				fmt.Fprintf(b, "%s%s%s", ansiFaint, letter, ansiReset)
+			default:
+				b.WriteString(letter)
+			}
+		}
+		b.WriteString("\n")
+	}
+}
+
+// renderHTML draws a page with the grid in a pre, matched letters are in a span with the class of their colour
+func (pic *Picture) renderHTML(b *strings.Builder) {
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Day 4</title>\n<style>\n")
+	b.WriteString("pre { font-family: monospace; font-size: 1.5em; line-height: 1.2; }\n")
+	b.WriteString(".dim { color: #999999; }\n")
+	for i, c := range webColours {
+		fmt.Fprintf(b, ".m%d { color: %s; font-weight: bold; }\n", i, c)
+	}
+	b.WriteString("</style>\n</head>\n<body>\n<pre>\n")
+	for y := 0; y < pic.Letters.Height; y++ {
+		for x := 0; x < pic.Letters.Width; x++ {
+			letter, owner := pic.cell(grid.Point{X: x, Y: y})
+			switch {
+			case owner >= 0:
+				fmt.Fprintf(b, "<span class=\"m%d\">%s</span>", owner%len(webColours), html.EscapeString(letter))
+			case pic.Dim:
+				fmt.Fprintf(b, "<span class=\"dim\">%s</span>", html.EscapeString(letter))
+			default:
+				b.WriteString(html.EscapeString(letter))
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("</pre>\n</body>\n</html>\n")
+}
+
+// renderSVG draws an image with a text per cell, and a line through each word or a box around each block
+func (pic *Picture) renderSVG(b *strings.Builder) {
+	width, height := pic.Letters.Width*svgCell, pic.Letters.Height*svgCell
+	fmt.Fprintf(b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		width, height, width, height)
+	fmt.Fprintf(b, "<rect width=\"%d\" height=\"%d\" fill=\"white\"/>\n", width, height)
+	b.WriteString("<g stroke-width=\"14\" stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-opacity=\"0.4\" fill=\"none\">\n")
+	for i, m := range pic.Matches {
+		pic.renderStroke(b, m, webColours[i%len(webColours)])
+	}
+	b.WriteString("</g>\n")
+	fmt.Fprintf(b, "<g font-family=\"monospace\" font-size=\"%d\" text-anchor=\"middle\" dominant-baseline=\"central\">\n",
+		svgCell*2/3)
+	for pt := range pic.Letters.Points() {
+		letter, owner := pic.cell(pt)
+		fill := "black"
+		if owner < 0 && pic.Dim {
+			fill = "#999999"
+		}
+		fmt.Fprintf(b, "<text x=\"%d\" y=\"%d\" fill=\"%s\">%s</text>\n",
+			svgCentre(pt.X), svgCentre(pt.Y), fill, html.EscapeString(letter))
+	}
+	b.WriteString("</g>\n</svg>\n")
+}
+
+// renderStroke draws a line from the first to the last letter of a word, or a box around the cells of a block
+func (pic *Picture) renderStroke(b *strings.Builder, m *Match, colour string) {
+	if len(m.Cells) == 0 {
+		return
+	}
+	if m.Direction != "" {
+		first, last := m.Cells[0], m.Cells[len(m.Cells)-1]
+		fmt.Fprintf(b, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\" stroke=\"%s\"/>\n",
+			svgCentre(first.X), svgCentre(first.Y), svgCentre(last.X), svgCentre(last.Y), colour)
+		return
+	}
+	lo, hi := m.Cells[0], m.Cells[0]
+	for _, c := range m.Cells[1:] {
+		lo = grid.Point{X: min(lo.X, c.X), Y: min(lo.Y, c.Y)}
+		hi = grid.Point{X: max(hi.X, c.X), Y: max(hi.Y, c.Y)}
+	}
+	fmt.Fprintf(b, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" rx=\"%d\" stroke=\"%s\" stroke-width=\"4\"/>\n",
+		lo.X*svgCell+2, lo.Y*svgCell+2, (hi.X-lo.X+1)*svgCell-4, (hi.Y-lo.Y+1)*svgCell-4, svgCell/4, colour)
+}
+
+// svgCentre returns the pixel coordinate of the centre of a cell from its column or row
+func svgCentre(i int) int {
+	return i*svgCell + svgCell/2
+}
+
+// newRenderCmd creates a new render command
+func newRenderCmd(h *common.Helpers, s *Solver) *cobra.Command {
+	var renderer, word, symmetry string
+	var blocks, dim bool
+	renderCmd := &cobra.Command{
+		Use:   "render",
+		Short: "Draw the puzzle with every match highlighted",
+		Long: fmt.Sprintf("Draw the puzzle with every match of --word, or of the block patterns with --blocks, highlighted. "+
+			"The renderer is one of %v, ansi colours the terminal, html writes a standalone page and svg an image "+
+			"with a stroke through each match", Renderers()),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := ParseRenderer(renderer)
+			if err != nil {
+				return err
+			}
+			sym, err := ParseSymmetry(symmetry)
+			if err != nil {
+				return err
+			}
+			return render(h, s, r, dim, func(h *common.Helpers, p *Puzzle) ([]*Match, error) {
+				if !blocks {
+					return p.FindWord(h, word)
+				}
+				targets, err := getPatterns(h)
+				if err != nil {
+					return nil, err
+				}
+				return p.FindBlocks(h, targets, sym)
+			})
+		},
+	}
+	renderCmd.Flags().StringVar(&renderer, "renderer", string(ANSIRenderer), fmt.Sprintf("renderer to draw with, one of %v", Renderers()))
+	renderCmd.Flags().StringVar(&word, "word", "XMAS", "word to highlight")
+	renderCmd.Flags().BoolVar(&blocks, "blocks", false, "highlight the block patterns of --pattern rather than a word")
+	renderCmd.Flags().StringVar(&symmetry, "symmetry", string(Rotations), fmt.Sprintf("orientations to match the patterns in with --blocks, one of %v", Symmetries()))
+	renderCmd.Flags().BoolVar(&dim, "dim", false, "replace the letters no match covers with dots")
+	return renderCmd
+}
+
+// render parses the puzzle, finds matches in it with f and draws them with a renderer
+func render(h *common.Helpers, s *Solver, r Renderer, dim bool, f func(h *common.Helpers, p *Puzzle) ([]*Match, error)) error {
+	h = h.With(common.DayAttr, day)
+	p, err := loadPuzzle(h.With(common.PhaseAttr, common.ParsePhase), s)
+	if err != nil {
+		return err
+	}
+	matches, err := f(h, p)
+	if err != nil {
+		return err
+	}
+	h.Logger.Debug("Rendering matches", "renderer", r, "matches", len(matches), "dim", dim)
+	pic := NewPicture(p, matches, dim)
+	pic.Color = common.IsTerminal(h.Streams.Out)
+	return pic.Render(h.Streams.Out, r)
+}
@@ -0,0 +1,103 @@
+package day4
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRender is a test for drawing a Picture with each renderer
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		name     string
+		renderer Renderer
+		dim      bool
+		color    bool
+		contains []string
+	}{
+		{
+			name:     "ansi",
+			renderer: ANSIRenderer,
+			color:    true,
+			contains: []string{"\x1b[1;31mX\x1b[0m\x1b[1;31mM\x1b[0m\x1b[1;31mA\x1b[0m\x1b[1;31mS\x1b[0mb\n<&.Sx\n"},
+		},
+		{
+			name:     "ansi_dim",
+			renderer: ANSIRenderer,
+			dim:      true,
+			color:    true,
+			contains: []string{"\x1b[1;31mS\x1b[0m\x1b[2m.\x1b[0m\n\x1b[2m.\x1b[0m"},
+		},
+		{
+			name:     "ansi_without_color",
+			renderer: ANSIRenderer,
+			dim:      true,
+			contains: []string{"XMAS.\n.....\n"},
+		},
+		{
+			name:     "html",
+			renderer: HTMLRenderer,
+			contains: []string{"<!DOCTYPE html>", "<span class=\"m0\">X</span>", "b\n&lt;&amp;.Sx\n</pre>"},
+		},
+		{
+			name:     "html_dim",
+			renderer: HTMLRenderer,
+			dim:      true,
+			contains: []string{"<span class=\"m0\">S</span><span class=\"dim\">.</span>\n"},
+		},
+		{
+			name:     "svg",
+			renderer: SVGRenderer,
+			contains: []string{
+				"<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"120\" height=\"48\"",
+				"<line x1=\"12\" y1=\"12\" x2=\"84\" y2=\"12\" stroke=\"#d62728\"/>",
+				"<text x=\"12\" y=\"36\" fill=\"black\">&lt;</text>",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			h, p := newTestPuzzle(t, "XMASb\n<&.Sx\n")
+			matches, err := p.FindWord(h, "XMAS")
+			assert.Nil(t, err)
+			var b strings.Builder
+			// Act
+			pic := NewPicture(p, matches, tc.dim)
+			pic.Color = tc.color
+			err = pic.Render(&b, tc.renderer)
+			// Assert
+			assert.Nil(t, err)
+			for _, c := range tc.contains {
+				assert.Contains(t, b.String(), c)
+			}
+		})
+	}
+}
+
+// TestRenderBlocks is a test for drawing the matches of a block pattern
+func TestRenderBlocks(t *testing.T) {
+	// Arrange
+	h, p := newTestPuzzle(t, "M.S\n.A.\nM.S\n")
+	matches, err := p.FindBlocks(h, xmasTargets(t), Rotations)
+	assert.Nil(t, err)
+	var b strings.Builder
+	// Act
+	err = NewPicture(p, matches, true).Render(&b, SVGRenderer)
+	// Assert
+	assert.Nil(t, err)
+	assert.Contains(t, b.String(), "<rect x=\"2\" y=\"2\" width=\"68\" height=\"68\" rx=\"6\" stroke=\"#d62728\" stroke-width=\"4\"/>")
+	assert.Contains(t, b.String(), "<text x=\"36\" y=\"12\" fill=\"#999999\">.</text>")
+}
+
+// TestParseRenderer is a test for the ParseRenderer function
+func TestParseRenderer(t *testing.T) {
+	for _, r := range Renderers() {
+		result, err := ParseRenderer(string(r))
+		assert.Nil(t, err)
+		assert.Equal(t, r, result)
+	}
+	_, err := ParseRenderer("png")
+	assert.Equal(t, ErrUnknownRenderer{Renderer: "png"}, err)
+}
@@ -0,0 +1,169 @@
+package day4
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common/grid"
+)
+
+// scanPattern is a block pattern compiled for scanning, the offsets and letters of the cells that aren't wildcards
+type scanPattern struct {
+	width   int
+	height  int
+	offsets []grid.Point
+	letters []Symbol
+}
+
+// compilePattern compiles a block pattern for scanning, its letters become their keys in an alphabet
+func compilePattern(g *grid.Grid[Cell], a *Alphabet) scanPattern {
+	sp := scanPattern{width: g.Width, height: g.Height}
+	for pt, c := range g.All() {
+		// skip wildcards
+		if c.Letter == c_space.Letter {
+			continue
+		}
+		sp.offsets = append(sp.offsets, pt)
+		sp.letters = append(sp.letters, a.Key(c.Letter))
+	}
+	return sp
+}
+
+// compileVariants compiles each variant of a block pattern for scanning
+func compileVariants(vs []Variant, a *Alphabet) []scanPattern {
+	sps := make([]scanPattern, 0, len(vs))
+	for _, v := range vs {
+		sps = append(sps, compilePattern(v.Grid, a))
+	}
+	return sps
+}
+
+// fits checks if n cells from a point in a direction are all in the grid
+func fits(g *grid.Grid[Symbol], x int, y int, dir grid.Point, n int) bool {
+	ex, ey := x+dir.X*(n-1), y+dir.Y*(n-1)
+	return ex >= 0 && ey >= 0 && ex < g.Width && ey < g.Height
+}
+
+// scanWord iterates over the start and direction of every match of a word, row by row and then clockwise from N,
+// the grid is read in place by stepping through its cells with each direction as an index offset
+func scanWord(g *grid.Grid[Symbol], word []Symbol) iter.Seq2[grid.Point, grid.Point] {
+	return func(yield func(grid.Point, grid.Point) bool) {
+		if len(word) == 0 {
+			return
+		}
+		dirs := grid.Directions()
+		steps := make([]int, len(dirs))
+		for i, d := range dirs {
+			steps[i] = d.Y*g.Width + d.X
+		}
+		for y := 0; y < g.Height; y++ {
+			row := y * g.Width
+			for x := 0; x < g.Width; x++ {
+				if g.Cells[row+x] != word[0] {
+					continue
+				}
+				for i, d := range dirs {
+					if !fits(g, x, y, d, len(word)) {
+						continue
+					}
+					j, k := row+x, 1
+					for ; k < len(word); k++ {
+						j += steps[i]
+						if g.Cells[j] != word[k] {
+							break
+						}
+					}
+					if k == len(word) && !yield(grid.Point{X: x, Y: y}, d) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// scanBlock iterates over the top left of every match of a compiled block pattern, row by row,
+// the grid is read in place rather than copying a window at each point
+func scanBlock(g *grid.Grid[Symbol], sp scanPattern) iter.Seq[grid.Point] {
+	return func(yield func(grid.Point) bool) {
+		deltas := make([]int, len(sp.offsets))
+		for i, o := range sp.offsets {
+			deltas[i] = o.Y*g.Width + o.X
+		}
+		for y := 0; y <= g.Height-sp.height; y++ {
+			for x := 0; x <= g.Width-sp.width; x++ {
+				base := y*g.Width + x
+				match := true
+				for i, d := range deltas {
+					if g.Cells[base+d] != sp.letters[i] {
+						match = false
+						break
+					}
+				}
+				if match && !yield(grid.Point{X: x, Y: y}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// scanVariants iterates over the top left and variant index of every match of the compiled variants of a block
+// pattern, a match covering the same cells as an earlier one is left out. Variants whose letters are at the same
+// offsets from their first letter (their shape) cover the same cells when their first letters are at the same point,
+// e.g. the rotations of "A " only differ in where the wildcard pads the A
+func scanVariants(g *grid.Grid[Symbol], sps []scanPattern) iter.Seq2[grid.Point, int] {
+	return func(yield func(grid.Point, int) bool) {
+		shapes := make([]int, len(sps))
+		ids := make(map[string]int)
+		for i, sp := range sps {
+			shape := shapeOf(sp)
+			id, ok := ids[shape]
+			if !ok {
+				id = len(ids)
+				ids[shape] = id
+			}
+			shapes[i] = id
+		}
+		// only remember matches if some variants share a shape, they can't cover the same cells otherwise
+		var seen map[shapeMatch]bool
+		if len(ids) < len(sps) {
+			seen = make(map[shapeMatch]bool)
+		}
+		for i, sp := range sps {
+			for origin := range scanBlock(g, sp) {
+				if seen != nil {
+					m := shapeMatch{shape: shapes[i], first: origin}
+					if len(sp.offsets) > 0 {
+						m.first = origin.Add(sp.offsets[0])
+					}
+					if seen[m] {
+						continue
+					}
+					seen[m] = true
+				}
+				if !yield(origin, i) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// shapeMatch is the struct for the cells a match covers, the shape of its variant and where its first letter is
+type shapeMatch struct {
+	shape int
+	first grid.Point
+}
+
+// shapeOf returns the offsets of the letters of a compiled pattern from its first letter, as a string to compare
+func shapeOf(sp scanPattern) string {
+	if len(sp.offsets) == 0 {
+		return ""
+	}
+	shape := make([]grid.Point, 0, len(sp.offsets))
+	for _, o := range sp.offsets {
+		shape = append(shape, o.Sub(sp.offsets[0]))
+	}
+	return fmt.Sprint(shape)
+}
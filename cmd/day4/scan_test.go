@@ -0,0 +1,65 @@
+package day4
+
+import (
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common/grid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestScan is a test for the scanWord and scanBlock functions at the edges of the grid
+func TestScan(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          string
+		word           string
+		pattern        Sets
+		expectedWords  int
+		expectedBlocks []grid.Point
+	}{
+		{
+			name:           "word_and_block_too_big",
+			input:          "XM\nAS\n",
+			word:           "XMA",
+			pattern:        Sets{ms, a, ms},
+			expectedWords:  0,
+			expectedBlocks: []grid.Point{},
+		},
+		{
+			name:           "corners",
+			input:          "X.X\n...\nX.X\n",
+			word:           "X.X",
+			pattern:        Sets{{c_space, c_space, Cell{Letter: "X"}}},
+			expectedWords:  12,
+			expectedBlocks: []grid.Point{{X: 0, Y: 0}, {X: 0, Y: 2}},
+		},
+		{
+			name:           "single_cell",
+			input:          "A\n",
+			word:           "A",
+			pattern:        Sets{s_a},
+			expectedWords:  8,
+			expectedBlocks: []grid.Point{{X: 0, Y: 0}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			_, p := newTestPuzzle(t, tc.input)
+			pg, err := grid.FromRows(tc.pattern)
+			assert.Nil(t, err)
+			// Act
+			words := 0
+			for range scanWord(p.keys, p.Alphabet.Keys(tc.word)) {
+				words++
+			}
+			blocks := make([]grid.Point, 0)
+			for origin := range scanBlock(p.keys, compilePattern(pg, p.Alphabet)) {
+				blocks = append(blocks, origin)
+			}
+			// Assert
+			assert.Equal(t, tc.expectedWords, words)
+			assert.Equal(t, tc.expectedBlocks, blocks)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package day4
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/grid"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/parse"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// CountWords returns the count and matches of every word in the puzzle, in the order of the words with duplicates
+// left out, finding them all in a single pass over every line in each of the 8 directions.
+//
+// Each word is counted exactly as CountWord counts it:
+//   - a word is found in every direction it reads in, so a palindrome like ABA is found twice on the same cells,
+//     once each way, and a single letter is found 8 times, once per direction
+//   - overlapping matches all count, AA is found twice in AAA in each direction, and a word inside another word,
+//     like MAS in XMAS, is found as well
+func (p *Puzzle) CountWords(h *common.Helpers, words []string) ([]*Found, error) {
+	found := make([]*Found, 0, len(words))
+	letters := make([][]Symbol, 0, len(words))
+	for _, word := range words {
+		if word == "" {
+			h.Logger.Error("Error counting words", common.ErrAttr, ErrEmptyWord{})
+			return nil, ErrEmptyWord{}
+		}
+		if slices.ContainsFunc(found, func(f *Found) bool { return f.Word == word }) {
+			continue
+		}
+		found = append(found, &Found{Word: word, Matches: make([]*Match, 0)})
+		letters = append(letters, p.Alphabet.Keys(word))
+	}
+	a := newAutomaton(letters)
+	for _, dir := range grid.Directions() {
+		for _, start := range p.keys.LineStarts(dir) {
+			state := 0
+			for pt, k := range p.keys.Line(start, dir) {
+				state = a.step(state, k)
+				for _, i := range a.ends(state) {
+					n := a.lengths[i]
+					found[i].Matches = append(found[i].Matches, newWordMatch(pt.Sub(dir.Scale(n-1)), dir, n))
+				}
+			}
+		}
+	}
+	for _, f := range found {
+		// the matches were found line by line, sort them by direction and then row by row like FindWord
+		slices.SortStableFunc(f.Matches, compareWordMatches)
+		f.Total = len(f.Matches)
+		f.Tally = Tally(f.Matches)
+	}
+	h.Logger.Debug("Counted words", "words", len(found))
+	return found, nil
+}
+
+// newWordMatch returns the match of a word of a length that starts at a point, reading in a direction
+func newWordMatch(start grid.Point, dir grid.Point, length int) *Match {
+	cells := make([]grid.Point, 0, length)
+	for i := 0; i < length; i++ {
+		cells = append(cells, start.Add(dir.Scale(i)))
+	}
+	return &Match{
+		Start:     start,
+		Direction: dir.Name(),
+		Cells:     cells,
+	}
+}
+
+// compareWordMatches orders matches by direction clockwise from N, and then by their start row by row
+func compareWordMatches(a *Match, b *Match) int {
+	dirs := grid.Directions()
+	byName := func(name string) int {
+		return slices.IndexFunc(dirs, func(d grid.Point) bool { return d.Name() == name })
+	}
+	if c := byName(a.Direction) - byName(b.Direction); c != 0 {
+		return c
+	}
+	if c := a.Start.Y - b.Start.Y; c != 0 {
+		return c
+	}
+	return a.Start.X - b.Start.X
+}
+
+// ParseWords parses a words file, a word per line, blank lines are skipped and spaces around a word are trimmed
+func ParseWords(resource string, contents []byte) ([]string, error) {
+	lines := parse.Lines(resource, contents)
+	if len(lines) == 0 {
+		return nil, parse.ErrParse{Resource: resource, Err: parse.ErrEmpty{}}
+	}
+	words := make([]string, 0, len(lines))
+	for _, l := range lines {
+		words = append(words, strings.TrimSpace(l.Text))
+	}
+	return words, nil
+}
+
+// newSearchCmd creates a new search command
+func newSearchCmd(h *common.Helpers, s *Solver) *cobra.Command {
+	var wordsFile string
+	searchCmd := &cobra.Command{
+		Use:   "search",
+		Short: "Count every word of a words file in the puzzle",
+		Long: "Count every word of a words file in the puzzle in a single pass, words are counted in each direction they " +
+			"read in (so palindromes count twice) and overlapping matches all count, json and yaml include the matches",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return search(h, s, wordsFile)
+		},
+	}
+	searchCmd.Flags().StringVar(&wordsFile, "words-file", "", "file of words to count, a word per line")
+	_ = searchCmd.MarkFlagRequired("words-file")
+	return searchCmd
+}
+
+// search parses the puzzle and writes the count of every word of a words file in the configured format
+func search(h *common.Helpers, s *Solver, wordsFile string) error {
+	h = h.With(common.DayAttr, day)
+	format, err := common.GetOutputFormat(h, common.TableOutput)
+	if err != nil {
+		return err
+	}
+	contents, err := os.ReadFile(wordsFile)
+	if err != nil {
+		h.Logger.Error("Error reading words", common.ErrAttr, err)
+		return err
+	}
+	words, err := ParseWords(wordsFile, contents)
+	if err != nil {
+		return err
+	}
+	p, err := loadPuzzle(h.With(common.PhaseAttr, common.ParsePhase), s)
+	if err != nil {
+		return err
+	}
+	found, err := p.CountWords(h, words)
+	if err != nil {
+		return err
+	}
+	return renderWords(h.Streams.Out, format, found)
+}
+
+// renderWords writes the counts of words to a writer in a format, text and table write a row per word with its tally
+// and answer only writes the counts
+func renderWords(w io.Writer, format common.OutputFormat, found []*Found) error {
+	switch format {
+	case common.TextOutput, common.TableOutput:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "WORD\tCOUNT\tTALLY")
+		for _, f := range found {
+			tally := make([]string, 0, len(f.Tally))
+			for _, k := range TallyKeys(f.Matches) {
+				tally = append(tally, fmt.Sprintf("%s=%d", k, f.Tally[k]))
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%s\n", f.Word, f.Total, strings.Join(tally, " "))
+		}
+		return tw.Flush()
+	case common.JSONOutput:
+		e := json.NewEncoder(w)
+		e.SetIndent("", "  ")
+		return e.Encode(found)
+	case common.YAMLOutput:
+		e := yaml.NewEncoder(w)
+		e.SetIndent(2)
+		err := e.Encode(found)
+		if err != nil {
+			return err
+		}
+		return e.Close()
+	case common.AnswerOutput:
+		for _, f := range found {
+			_, err := fmt.Fprintln(w, f.Total)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return common.ErrUnknownOutput{Format: format}
+	}
+}
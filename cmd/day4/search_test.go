@@ -0,0 +1,68 @@
+package day4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCountWords is a test for the CountWords function, every word must be counted as CountWord counts it
+func TestCountWords(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		words    []string
+		expected map[string]int
+	}{
+		{
+			name:     "example",
+			input:    example,
+			words:    []string{"XMAS", "SAMX", "MAS", "AS", "XMAS", "M", "MM", "MMM", "ZZZ"},
+			expected: map[string]int{"XMAS": 18, "SAMX": 18, "MAS": 38, "AS": 53, "M": 304, "MM": 78, "MMM": 20, "ZZZ": 0},
+		},
+		{
+			name:     "palindrome_both_ways",
+			input:    "ABA\n",
+			words:    []string{"ABA", "B"},
+			expected: map[string]int{"ABA": 2, "B": 8},
+		},
+		{
+			name:     "overlapping",
+			input:    "AAA\n",
+			words:    []string{"AA", "AAA"},
+			expected: map[string]int{"AA": 4, "AAA": 2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			h, p := newTestPuzzle(t, tc.input)
+			// Act
+			result, err := p.CountWords(h, tc.words)
+			// Assert
+			assert.Nil(t, err)
+			counts := make(map[string]int)
+			for _, f := range result {
+				counts[f.Word] = f.Total
+				count, err := p.CountWord(h, f.Word)
+				assert.Nil(t, err)
+				assert.Equal(t, count, f.Total, f.Word)
+				matches, err := p.FindWord(h, f.Word)
+				assert.Nil(t, err)
+				assert.Equal(t, matches, f.Matches, f.Word)
+			}
+			assert.Equal(t, tc.expected, counts)
+		})
+	}
+}
+
+// TestCountWordsEmpty is a test that CountWords fails on an empty word
+func TestCountWordsEmpty(t *testing.T) {
+	// Arrange
+	h, p := newTestPuzzle(t, example)
+	// Act
+	result, err := p.CountWords(h, []string{"XMAS", ""})
+	// Assert
+	assert.Equal(t, ErrEmptyWord{}, err)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,98 @@
+package day4
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common/grid"
+)
+
+const (
+	// NoSymmetry matches a block pattern only as it's given
+	NoSymmetry = Symmetry("none")
+	// Rotations matches a block pattern in its 4 rotations
+	Rotations = Symmetry("rotations")
+	// Dihedral matches a block pattern in its 4 rotations and the 4 rotations of its mirror image
+	Dihedral = Symmetry("dihedral")
+)
+
+// Symmetry is the set of orientations a block pattern is matched in
+type Symmetry string
+
+// Symmetries returns every symmetry
+func Symmetries() []Symmetry {
+	return []Symmetry{NoSymmetry, Rotations, Dihedral}
+}
+
+// ErrUnknownSymmetry is an error that is returned for a symmetry that doesn't exist
+type ErrUnknownSymmetry struct {
+	Symmetry string
+}
+
+// Error returns the error message
+func (e ErrUnknownSymmetry) Error() string {
+	return fmt.Sprintf("unknown symmetry %q, expected one of %v", e.Symmetry, Symmetries())
+}
+
+// ParseSymmetry returns the symmetry with a name
+func ParseSymmetry(name string) (Symmetry, error) {
+	for _, s := range Symmetries() {
+		if string(s) == name {
+			return s, nil
+		}
+	}
+	return "", ErrUnknownSymmetry{Symmetry: name}
+}
+
+// symmetryOf returns the symmetry for the rotate argument of CountBlocks
+func symmetryOf(rotate bool) Symmetry {
+	if rotate {
+		return Rotations
+	}
+	return NoSymmetry
+}
+
+// Variant is the struct for an orientation of a block pattern
+type Variant struct {
+	// Rotation is the degrees clockwise the pattern is rotated by
+	Rotation int
+	// Reflected is true if the pattern is mirrored left to right before it's rotated
+	Reflected bool
+	Grid      *grid.Grid[Cell]
+}
+
+// variants returns the distinct orientations of a block pattern in a symmetry, an orientation that looks the same as
+// an earlier one (e.g. every rotation of a pattern symmetric under rotation) is left out so it isn't matched twice
+func variants(g *grid.Grid[Cell], sym Symmetry) ([]Variant, error) {
+	rotations := 1
+	reflections := []bool{false}
+	switch sym {
+	case NoSymmetry:
+	case Rotations:
+		rotations = 4
+	case Dihedral:
+		rotations = 4
+		reflections = []bool{false, true}
+	default:
+		return nil, ErrUnknownSymmetry{Symmetry: string(sym)}
+	}
+	vs := make([]Variant, 0, rotations*len(reflections))
+	for _, reflected := range reflections {
+		base := g
+		if reflected {
+			base = g.FlipH()
+		}
+		for r := 0; r < rotations; r++ {
+			v := Variant{Rotation: r * 90, Reflected: reflected, Grid: base.Rotate(r)}
+			if !slices.ContainsFunc(vs, v.sameAs) {
+				vs = append(vs, v)
+			}
+		}
+	}
+	return vs, nil
+}
+
+// sameAs checks if two variants look the same, wildcards included
+func (v Variant) sameAs(o Variant) bool {
+	return v.Grid.Width == o.Grid.Width && v.Grid.Height == o.Grid.Height && slices.Equal(v.Grid.Cells, o.Grid.Cells)
+}
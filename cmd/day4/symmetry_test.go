@@ -0,0 +1,106 @@
+package day4
+
+import (
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common/grid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVariants is a test for the variants function
+func TestVariants(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    Sets
+		sym      Symmetry
+		expected int
+	}{
+		{name: "xmas_none", input: Sets{ms, a, ms}, sym: NoSymmetry, expected: 1},
+		{name: "xmas_rotations", input: Sets{ms, a, ms}, sym: Rotations, expected: 4},
+		{name: "xmas_dihedral_reflections_are_rotations", input: Sets{ms, a, ms}, sym: Dihedral, expected: 4},
+		{name: "single_cell_rotations", input: Sets{s_a}, sym: Rotations, expected: 1},
+		{name: "line_rotations", input: Sets{ms}, sym: Rotations, expected: 4},
+		{name: "palindrome_line_rotations", input: Sets{mm}, sym: Rotations, expected: 2},
+		{name: "line_dihedral_reflections_are_rotations", input: Sets{ms}, sym: Dihedral, expected: 4},
+		{name: "corner_dihedral", input: Sets{s2_mspace, s2_aspace}, sym: Dihedral, expected: 8},
+		{name: "diagonal_dihedral", input: Sets{s2_mspace, s2_spacem}, sym: Dihedral, expected: 2},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			g, err := grid.FromRows(tc.input)
+			assert.Nil(t, err)
+			// Act
+			result, err := variants(g, tc.sym)
+			// Assert
+			assert.Nil(t, err)
+			assert.Len(t, result, tc.expected)
+		})
+	}
+}
+
+// TestCountBlocksWithSymmetry is a test for the CountBlocksWithSymmetry function, and that FindBlocks agrees with it
+func TestCountBlocksWithSymmetry(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		targets  []Sets
+		sym      Symmetry
+		expected int
+	}{
+		{name: "xmas_none", targets: xmasTargets(t), sym: NoSymmetry, expected: 2},
+		{name: "xmas_rotations", targets: xmasTargets(t), sym: Rotations, expected: 9},
+		{name: "xmas_dihedral", targets: xmasTargets(t), sym: Dihedral, expected: 9},
+		{name: "single_cell_counted_once", targets: []Sets{{s_a}}, sym: Dihedral, expected: 24},
+		{name: "line_rotations", targets: []Sets{{{c_a, c_m}}}, sym: Rotations, expected: 36},
+		{name: "padded_letter_none", input: "AAA\n", targets: []Sets{{{c_a, c_space}}}, sym: NoSymmetry, expected: 2},
+		{name: "padded_letter_rotations_once_per_cell", input: "AAA\n", targets: []Sets{{{c_a, c_space}}}, sym: Rotations,
+			expected: 3},
+		{name: "padded_letter_dihedral_once_per_cell", input: "AA\nAA\n", targets: []Sets{{{c_a, c_space}}}, sym: Dihedral,
+			expected: 4},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			input := tc.input
+			if input == "" {
+				input = example
+			}
+			h, p := newTestPuzzle(t, input)
+			// Act
+			result, err := p.CountBlocksWithSymmetry(h, tc.targets, tc.sym)
+			matches, findErr := p.FindBlocks(h, tc.targets, tc.sym)
+			// Assert
+			assert.Nil(t, err)
+			assert.Nil(t, findErr)
+			assert.Equal(t, tc.expected, result)
+			assert.Len(t, matches, tc.expected)
+		})
+	}
+}
+
+// TestCountBlocksRotate is a test that CountBlocks only rotates when asked to
+func TestCountBlocksRotate(t *testing.T) {
+	// Arrange
+	h, p := newTestPuzzle(t, example)
+	// Act
+	rotated, err := p.CountBlocks(h, xmasTargets(t), true)
+	assert.Nil(t, err)
+	unrotated, err := p.CountBlocks(h, xmasTargets(t), false)
+	assert.Nil(t, err)
+	// Assert
+	assert.Equal(t, 9, rotated)
+	assert.Equal(t, 2, unrotated)
+}
+
+// TestParseSymmetry is a test for the ParseSymmetry function
+func TestParseSymmetry(t *testing.T) {
+	// Arrange
+	// Act
+	sym, err := ParseSymmetry("dihedral")
+	_, unknownErr := ParseSymmetry("mirror")
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, Dihedral, sym)
+	assert.Equal(t, ErrUnknownSymmetry{Symmetry: "mirror"}, unknownErr)
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/mrlunchbox777/2024-advent-of-code/cmd/day1"
+	"github.com/mrlunchbox777/2024-advent-of-code/cmd/day2"
+	"github.com/mrlunchbox777/2024-advent-of-code/cmd/day3"
+	"github.com/mrlunchbox777/2024-advent-of-code/cmd/day4"
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+)
+
+// newRegistry creates a registry with every day registered
+func newRegistry() (*common.Registry, error) {
+	r := common.NewRegistry()
+	solvers := []common.Solver{
+		day1.NewSolver(),
+		day2.NewSolver(),
+		day3.NewSolver(),
+		day4.NewSolver(),
+	}
+	for _, s := range solvers {
+		if err := r.Register(s); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
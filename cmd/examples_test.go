@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestExamples is a test for every registered example of every day
+func TestExamples(t *testing.T) {
+	// Arrange
+	s := test.NewTestStreams()
+	l := test.NewTestSlog(s.Streams)
+	v := test.NewTestViper(t)
+	h, err := common.NewHelpers(s.Streams, v, l)
+	if err != nil {
+		l.Error(err.Error())
+		t.Log(err)
+		t.Fail()
+	}
+	r, err := newRegistry()
+	assert.Nil(t, err)
+	// Act & Assert
+	test.AssertExamples(t, h, r.Solvers()...)
+}
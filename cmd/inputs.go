@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/aoc"
+	"github.com/spf13/cobra"
+)
+
+// newInputsCmd creates a new inputs command
+func newInputsCmd(h *common.Helpers) *cobra.Command {
+	inputsCmd := &cobra.Command{
+		Use:   "inputs",
+		Short: "Manage puzzle inputs",
+		Long:  "Manage puzzle inputs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("No subcommand given")
+		},
+	}
+
+	inputsCmd.AddCommand(newInputsFetchCmd(h))
+
+	return inputsCmd
+}
+
+// newInputsFetchCmd creates a new inputs fetch command
+func newInputsFetchCmd(h *common.Helpers) *cobra.Command {
+	var day int
+	fetchCmd := &cobra.Command{
+		Use:   "fetch",
+		Short: "Download a day's puzzle input into the cache",
+		Long:  "Download a day's puzzle input into the cache, inputs that are already on disk are never downloaded again",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fetchInput(h, day)
+		},
+	}
+	fetchCmd.Flags().IntVar(&day, "day", 0, "day to fetch the input for")
+	_ = fetchCmd.MarkFlagRequired("day")
+	return fetchCmd
+}
+
+// fetchInput downloads the input for a day into the cache
+func fetchInput(h *common.Helpers, day int) error {
+	if day < 1 || day > 25 {
+		return fmt.Errorf("day must be between 1 and 25, got %d", day)
+	}
+	c, err := aoc.NewClient(h)
+	if err != nil {
+		h.Logger.Error("Error creating client", common.ErrAttr, err)
+		return err
+	}
+	f, fetched, err := c.CacheInput(h, day)
+	if err != nil {
+		return err
+	}
+	status := "already cached"
+	if fetched {
+		status = "fetched"
+	}
+	_, err = h.Streams.Out.Write([]byte(fmt.Sprintf("%s %s: %s\n", f.Name, status, f.Layer)))
+	return err
+}
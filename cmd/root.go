@@ -1,26 +1,85 @@
 package cmd
 
 import (
-	"github.com/mrlunchbox777/2024-advent-of-code/cmd/day1"
-	"github.com/mrlunchbox777/2024-advent-of-code/cmd/day2"
-	"github.com/mrlunchbox777/2024-advent-of-code/cmd/day3"
-	"github.com/mrlunchbox777/2024-advent-of-code/cmd/day4"
+	"fmt"
+
 	"github.com/mrlunchbox777/2024-advent-of-code/common"
 	"github.com/spf13/cobra"
 )
 
 // NewRootCmd creates a new root command
-func NewRootCmd(h *common.Helpers) *cobra.Command {
+func NewRootCmd(h *common.Helpers) (*cobra.Command, error) {
 	rootCmd := &cobra.Command{
 		Use:   "2024-advent-of-code",
 		Short: "2024 Advent of Code",
 		Long:  "2024 Advent of Code",
 	}
+	rootCmd.SetIn(h.Streams.In)
+	rootCmd.SetOut(h.Streams.Out)
+	rootCmd.SetErr(h.Streams.ErrOut)
+
+	flags := rootCmd.PersistentFlags()
+	flags.String(common.ConfigKey, "", "path to the config file (defaults to $XDG_CONFIG_HOME/aoc2024/config.yaml)")
+	flags.String(common.LogLevelKey, "info", "log level, one of debug, info, warn, error")
+	flags.String(common.LogFormatKey, common.JSONLogFormat, fmt.Sprintf("log format, one of %v", common.LogFormats()))
+	flags.String(common.LogFileKey, "", "file logs are appended to (defaults to stderr)")
+	flags.String(common.InputKey, "", "path to the puzzle input, - for stdin (defaults to the resources)")
+	flags.Int(common.ExampleKey, 0, "number of the example to use as the puzzle input")
+	flags.StringSlice(common.InputDirsKey, nil, "directories searched for resources before the cache and embedded resources")
+	flags.String(common.CacheDirKey, "", "directory downloaded resources are cached in (defaults to the user cache dir)")
+	flags.String(common.LedgerKey, common.DefaultLedger, "path to the ledger of accepted answers")
+	flags.StringP(common.OutputKey, "o", "", fmt.Sprintf("output format of results, one of %v (defaults to text for a star, table for run-all)", common.OutputFormats()))
+	keys := []string{
+		common.ConfigKey,
+		common.LogLevelKey,
+		common.LogFormatKey,
+		common.LogFileKey,
+		common.InputKey,
+		common.ExampleKey,
+		common.InputDirsKey,
+		common.CacheDirKey,
+		common.LedgerKey,
+		common.OutputKey,
+	}
+	for _, key := range keys {
+		err := h.Viper.BindPFlag(key, flags.Lookup(key))
+		if err != nil {
+			return nil, err
+		}
+	}
+	err := addAOCFlags(h, rootCmd)
+	if err != nil {
+		return nil, err
+	}
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		// the config file, logger and resource layers depend on flags, so load them once they are parsed
+		return h.LoadConfig()
+	}
 
-	rootCmd.AddCommand(day1.NewCmd(h))
-	rootCmd.AddCommand(day2.NewCmd(h))
-	rootCmd.AddCommand(day3.NewCmd(h))
-	rootCmd.AddCommand(day4.NewCmd(h))
+	r, err := newRegistry()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range r.Solvers() {
+		dayCmd, err := newDayCmd(h, s)
+		if err != nil {
+			return nil, err
+		}
+		rootCmd.AddCommand(dayCmd)
+	}
+
+	rootCmd.AddCommand(newInputsCmd(h))
+	rootCmd.AddCommand(newSubmitCmd(h, r))
+	rootCmd.AddCommand(newVerifyCmd(h, r))
+
+	runAllCmd, err := newRunAllCmd(h, r)
+	if err != nil {
+		return nil, err
+	}
+	rootCmd.AddCommand(runAllCmd)
+	rootCmd.AddCommand(newBenchCmd(h, r))
+	rootCmd.AddCommand(newScaffoldCmd(h))
+	rootCmd.AddCommand(newConfigCmd(h, r))
 
-	return rootCmd
+	return rootCmd, nil
 }
@@ -0,0 +1,343 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/cmd/day4"
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/parse"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInputFromStdin is a test for reading the input from the in stream
+func TestInputFromStdin(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		input    string
+		expected string
+	}{
+		{
+			name:     "day1_star1",
+			args:     []string{"day1", "star1", "--input", "-"},
+			input:    "3   4\n4   3\n2   5\n1   3\n3   9\n3   3\n",
+			expected: "Day 1 Star 1: 11\n",
+		},
+		{
+			name:     "day1_star2",
+			args:     []string{"day1", "star2", "--input", "-"},
+			input:    "3   4\n4   3\n2   5\n1   3\n3   9\n3   3\n",
+			expected: "Day 1 Star 2: 31\n",
+		},
+		{
+			name:     "day2_star1",
+			args:     []string{"day2", "star1", "--input", "-"},
+			input:    "7 6 4 2 1\n1 2 7 8 9\n9 7 6 2 1\n1 3 2 4 5\n8 6 4 4 1\n1 3 6 7 9\n",
+			expected: "Day 2 Star 1: 2\n",
+		},
+		{
+			name:     "day4_find",
+			args:     []string{"day4", "find", "--word", "XMAS", "--input", "-"},
+			input:    "XMAS\n.M..\n..A.\n...S\n",
+			expected: "XMAS E (0,0): (0,0) (1,0) (2,0) (3,0)\nXMAS SE (0,0): (0,0) (1,1) (2,2) (3,3)\nE: 1\nSE: 1\ntotal: 2\n",
+		},
+		{
+			name:     "day4_match",
+			args:     []string{"day4", "match", "--output", "answer", "--input", "-"},
+			input:    "M.S\n.A.\nM.S\n",
+			expected: "1\n",
+		},
+		{
+			name:     "day4_render_not_a_terminal",
+			args:     []string{"day4", "render", "--word", "XM", "--dim", "--input", "-"},
+			input:    "XM\nAS\n",
+			expected: "XM\n..\n",
+		},
+		{
+			name:     "day4_find_ignore_case",
+			args:     []string{"day4", "find", "--word", "Σοφία", "--ignore-case", "--output", "answer", "--input", "-"},
+			input:    "ΣΟΦΊΑ\nσοφία\n",
+			expected: "2\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			s := test.NewTestStreams()
+			l := test.NewTestSlog(s.Streams)
+			v := test.NewTestViper(t)
+			h, err := common.NewHelpers(s.Streams, v, l)
+			if err != nil {
+				l.Error(err.Error())
+				t.Log(err)
+				t.Fail()
+			}
+			s.BufIn.WriteString(tc.input)
+			rootCmd, err := NewRootCmd(h)
+			assert.Nil(t, err)
+			rootCmd.SetArgs(tc.args)
+			// Act
+			err = rootCmd.Execute()
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, s.BufInOut.String())
+		})
+	}
+}
+
+// TestLogAttributes is a test for the day, star and phase attributes on the solver's logs
+func TestLogAttributes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		expected map[string]bool
+	}{
+		{
+			name:     "debug",
+			args:     []string{"day1", "star1", "--log-level", "debug"},
+			expected: map[string]bool{common.ParsePhase: true, common.SolvePhase: true},
+		},
+		{
+			name:     "info",
+			args:     []string{"day1", "star1", "--log-level", "info"},
+			expected: map[string]bool{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			s := test.NewTestStreams()
+			l := test.NewTestSlog(s.Streams)
+			v := test.NewTestViper(t)
+			h, err := common.NewHelpers(s.Streams, v, l)
+			if err != nil {
+				l.Error(err.Error())
+				t.Log(err)
+				t.Fail()
+			}
+			rootCmd, err := NewRootCmd(h)
+			assert.Nil(t, err)
+			rootCmd.SetArgs(tc.args)
+			s.BufInErrOut.Reset()
+			// Act
+			err = rootCmd.Execute()
+			// Assert
+			assert.Nil(t, err)
+			phases := make(map[string]bool)
+			for _, line := range strings.Split(strings.TrimSpace(s.BufInErrOut.String()), "\n") {
+				record := make(map[string]any)
+				if !assert.Nil(t, json.Unmarshal([]byte(line), &record), line) {
+					continue
+				}
+				phase, ok := record[common.PhaseAttr].(string)
+				if !ok {
+					continue
+				}
+				assert.Equal(t, float64(1), record[common.DayAttr], line)
+				if phase == common.SolvePhase {
+					assert.Equal(t, float64(1), record[common.StarAttr], line)
+				}
+				phases[phase] = true
+			}
+			assert.Equal(t, tc.expected, phases)
+		})
+	}
+}
+
+// TestMalformedInput is a test for the position of parse errors in the puzzle input
+func TestMalformedInput(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		input    string
+		expected string
+	}{
+		{
+			name:     "day1_not_int",
+			args:     []string{"day1", "star1", "--input", "-"},
+			input:    "3   4\n4   x\n",
+			expected: `stdin:2:5: "x" isn't an integer`,
+		},
+		{
+			name:     "day1_too_many",
+			args:     []string{"day1", "star1", "--input", "-"},
+			input:    "3   4\n\n4   3   1\n",
+			expected: "stdin:3: expected 2 fields, got 3",
+		},
+		{
+			name:     "day2_not_int",
+			args:     []string{"day2", "star1", "--input", "-"},
+			input:    "7 6 4 2 1\n1 2 7 8 9.5\n",
+			expected: `stdin:2:9: "9.5" isn't an integer`,
+		},
+		{
+			name:     "day4_ragged",
+			args:     []string{"day4", "star1", "--input", "-"},
+			input:    "XMAS\nXMA\n",
+			expected: "stdin:2:4: expected a row of 4 cells, got 3",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			s := test.NewTestStreams()
+			l := test.NewTestSlog(s.Streams)
+			v := test.NewTestViper(t)
+			h, err := common.NewHelpers(s.Streams, v, l)
+			if err != nil {
+				l.Error(err.Error())
+				t.Log(err)
+				t.Fail()
+			}
+			s.BufIn.WriteString(tc.input)
+			rootCmd, err := NewRootCmd(h)
+			assert.Nil(t, err)
+			rootCmd.SetArgs(tc.args)
+			// Act
+			err = rootCmd.Execute()
+			// Assert
+			var parseErr parse.ErrParse
+			assert.ErrorAs(t, err, &parseErr)
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+// TestEmptyInput is a test for every day rejecting an empty input rather than answering 0
+func TestEmptyInput(t *testing.T) {
+	for _, day := range []string{"day1", "day2", "day3", "day4"} {
+		t.Run(day, func(t *testing.T) {
+			// Arrange
+			s := test.NewTestStreams()
+			h, err := common.NewHelpers(s.Streams, test.NewTestViper(t), test.NewTestSlog(s.Streams))
+			assert.Nil(t, err)
+			s.BufIn.WriteString(" \n\n")
+			rootCmd, err := NewRootCmd(h)
+			assert.Nil(t, err)
+			rootCmd.SetArgs([]string{day, "star1", "--input", "-"})
+			// Act
+			err = rootCmd.Execute()
+			// Assert
+			assert.Equal(t, common.ErrEmptyInput{Name: "stdin"}, err)
+		})
+	}
+}
+
+// TestDay4Pattern is a test for counting and matching the block patterns of a pattern file
+func TestDay4Pattern(t *testing.T) {
+	testCases := []struct {
+		name        string
+		args        []string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "star2",
+			args:     []string{"day4", "star2", "--wildcard", "#"},
+			expected: "Day 4 Star 2: 17\n",
+		},
+		{
+			name:     "match",
+			args:     []string{"day4", "match", "--wildcard", "#", "--symmetry", "none", "-o", "answer"},
+			expected: "5\n",
+		},
+		{
+			name:        "bad_wildcard",
+			args:        []string{"day4", "star2", "--wildcard", "##"},
+			expectedErr: day4.ErrWildcard{Wildcard: "##"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			s := test.NewTestStreams()
+			l := test.NewTestSlog(s.Streams)
+			v := test.NewTestViper(t)
+			h, err := common.NewHelpers(s.Streams, v, l)
+			if err != nil {
+				l.Error(err.Error())
+				t.Log(err)
+				t.Fail()
+			}
+			pattern := filepath.Join(t.TempDir(), "patterns.txt")
+			err = os.WriteFile(pattern, []byte("M#S\n#A#\nM#S\n\nXMAS\n"), 0o644)
+			assert.Nil(t, err)
+			rootCmd, err := NewRootCmd(h)
+			assert.Nil(t, err)
+			rootCmd.SetArgs(append(tc.args, "--example", "1", "--pattern", pattern))
+			// Act
+			err = rootCmd.Execute()
+			// Assert
+			assert.Equal(t, tc.expectedErr, err)
+			if tc.expectedErr == nil {
+				assert.Equal(t, tc.expected, s.BufInOut.String())
+			}
+		})
+	}
+}
+
+// TestDay4Search is a test for counting the words of a words file
+func TestDay4Search(t *testing.T) {
+	// Arrange
+	s := test.NewTestStreams()
+	l := test.NewTestSlog(s.Streams)
+	v := test.NewTestViper(t)
+	h, err := common.NewHelpers(s.Streams, v, l)
+	if err != nil {
+		l.Error(err.Error())
+		t.Log(err)
+		t.Fail()
+	}
+	words := filepath.Join(t.TempDir(), "words.txt")
+	err = os.WriteFile(words, []byte("XMAS\n\n  ABA \n"), 0o644)
+	assert.Nil(t, err)
+	s.BufIn.WriteString("XMASABA\n")
+	rootCmd, err := NewRootCmd(h)
+	assert.Nil(t, err)
+	rootCmd.SetArgs([]string{"day4", "search", "--words-file", words, "--input", "-"})
+	// Act
+	err = rootCmd.Execute()
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "WORD  COUNT  TALLY\nXMAS  1      E=1\nABA   2      E=1 W=1\n", s.BufInOut.String())
+}
+
+// TestDay4Generate is a test for generating a day 4 puzzle and solving it against its answer key
+func TestDay4Generate(t *testing.T) {
+	// Arrange
+	s := test.NewTestStreams()
+	l := test.NewTestSlog(s.Streams)
+	h, err := common.NewHelpers(s.Streams, test.NewTestViper(t), l)
+	assert.Nil(t, err)
+	file := filepath.Join(t.TempDir(), "puzzle.txt")
+	rootCmd, err := NewRootCmd(h)
+	assert.Nil(t, err)
+	rootCmd.SetArgs([]string{"day4", "generate", "--width", "30", "--height", "20", "--count", "6", "--seed", "3",
+		"--directions", "e,se,s", "--file", file})
+	// Act
+	err = rootCmd.Execute()
+	// Assert
+	assert.Nil(t, err)
+	contents, err := os.ReadFile(file + ".key.json")
+	assert.Nil(t, err)
+	key := &day4.AnswerKey{}
+	assert.Nil(t, json.Unmarshal(contents, key))
+	assert.Len(t, key.Placements, 6)
+	assert.GreaterOrEqual(t, key.Words["XMAS"], 6)
+	for star, expected := range map[string]int{"star1": key.Words["XMAS"], "star2": key.Blocks} {
+		s := test.NewTestStreams()
+		h, err := common.NewHelpers(s.Streams, test.NewTestViper(t), test.NewTestSlog(s.Streams))
+		assert.Nil(t, err)
+		rootCmd, err := NewRootCmd(h)
+		assert.Nil(t, err)
+		rootCmd.SetArgs([]string{"day4", star, "--input", file, "--output", "answer"})
+		assert.Nil(t, rootCmd.Execute())
+		assert.Equal(t, fmt.Sprintf("%d\n", expected), s.BufInOut.String(), star)
+	}
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// WorkersKey is the viper key for the number of solvers run at once
+	WorkersKey = "workers"
+)
+
+// ErrRunsFailed is an error that is returned when some stars failed to run
+type ErrRunsFailed struct {
+	Count int
+}
+
+// Error returns the error message
+func (e ErrRunsFailed) Error() string {
+	return fmt.Sprintf("%d star(s) failed", e.Count)
+}
+
+// ErrBadDays is an error that is returned when a day range can't be parsed
+type ErrBadDays struct {
+	Days string
+}
+
+// Error returns the error message
+func (e ErrBadDays) Error() string {
+	return fmt.Sprintf("bad day range %q, expected e.g. 1-3,5", e.Days)
+}
+
+// ErrRunAllInput is an error that is returned when run-all is given an input path or example, they're the input of
+// a single day
+type ErrRunAllInput struct{}
+
+// Error returns the error message
+func (e ErrRunAllInput) Error() string {
+	return fmt.Sprintf("run-all reads each day's own input, %s and %s can't be given", common.InputKey, common.ExampleKey)
+}
+
+// inputGiven returns true if an input path or example is given, they're the input of a single day
+func inputGiven(h *common.Helpers) bool {
+	return h.Viper.GetString(common.InputKey) != "" || h.Viper.GetInt(common.ExampleKey) > 0
+}
+
+// newRunAllCmd creates a new run-all command
+func newRunAllCmd(h *common.Helpers, r *common.Registry) (*cobra.Command, error) {
+	var days string
+	runAllCmd := &cobra.Command{
+		Use:   "run-all",
+		Short: "Run every day and star",
+		Long:  "Run every registered day and star concurrently and print a summary table",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if inputGiven(h) {
+				return ErrRunAllInput{}
+			}
+			solvers, err := selectSolvers(r, days)
+			if err != nil {
+				return err
+			}
+			return runAll(h, solvers, h.Viper.GetInt(WorkersKey))
+		},
+	}
+	runAllCmd.Flags().StringVar(&days, "days", "", "days to run, e.g. 1-3,5 (defaults to every day)")
+	runAllCmd.Flags().Int(WorkersKey, runtime.NumCPU(), "number of days run at once")
+	err := h.Viper.BindPFlag(WorkersKey, runAllCmd.Flags().Lookup(WorkersKey))
+	if err != nil {
+		return nil, err
+	}
+	return runAllCmd, nil
+}
+
+// selectSolvers returns the registered solvers in a day range, every solver if the range is empty
+func selectSolvers(r *common.Registry, days string) ([]common.Solver, error) {
+	if days == "" {
+		return r.Solvers(), nil
+	}
+	selected := make(map[int]bool)
+	for _, part := range strings.Split(days, ",") {
+		first, last, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, ErrBadDays{Days: days}
+		}
+		end := start
+		if isRange {
+			end, err = strconv.Atoi(last)
+			if err != nil || end < start {
+				return nil, ErrBadDays{Days: days}
+			}
+		}
+		for day := start; day <= end; day++ {
+			selected[day] = true
+		}
+	}
+	solvers := make([]common.Solver, 0, len(selected))
+	for _, s := range r.Solvers() {
+		if selected[s.Info().Day] {
+			solvers = append(solvers, s)
+		}
+	}
+	return solvers, nil
+}
+
+// runAll runs every star of the solvers with a bounded pool of workers and writes the results, a table by default
+func runAll(h *common.Helpers, solvers []common.Solver, workers int) error {
+	if workers < 1 {
+		workers = 1
+	}
+	// each solver writes into its own slot so the output keeps day order
+	results := make([][]*common.Result, len(solvers))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = runStars(h, solvers[i], common.Stars())
+			}
+		}()
+	}
+	for i := range solvers {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	all := make([]*common.Result, 0, len(solvers)*len(common.Stars()))
+	for _, dayResults := range results {
+		all = append(all, dayResults...)
+	}
+	err := h.WriteResults(common.TableOutput, all...)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, res := range all {
+		if res.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return ErrRunsFailed{Count: failed}
+	}
+	return nil
+}
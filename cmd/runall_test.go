@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSolver is a solver that answers with its day, or fails the way it is told to
+type fakeSolver struct {
+	day        int
+	parseErr   error
+	star2Panic bool
+}
+
+// Info returns the metadata for the fake day
+func (s *fakeSolver) Info() common.SolverInfo {
+	return common.SolverInfo{Day: s.day, Title: "Fake"}
+}
+
+// Parse returns the day as the input
+func (s *fakeSolver) Parse(h *common.Helpers, f *common.File) (any, error) {
+	return s.day, s.parseErr
+}
+
+// Star1 returns the day
+func (s *fakeSolver) Star1(h *common.Helpers, in any) (int, error) {
+	return in.(int), nil
+}
+
+// Star2 returns the day times ten, or panics
+func (s *fakeSolver) Star2(h *common.Helpers, in any) (int, error) {
+	if s.star2Panic {
+		panic("boom")
+	}
+	return in.(int) * 10, nil
+}
+
+// TestRunAll is a test for the runAll function
+func TestRunAll(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "input")
+	assert.Nil(t, os.WriteFile(input, []byte("input"), 0o644))
+	// Arrange
+	s := test.NewTestStreams()
+	l := test.NewTestSlog(s.Streams)
+	v := test.NewTestViper(t)
+	v.Set(common.InputKey, input)
+	h, err := common.NewHelpers(s.Streams, v, l)
+	if err != nil {
+		l.Error(err.Error())
+		t.Log(err)
+		t.Fail()
+	}
+	solvers := []common.Solver{
+		&fakeSolver{day: 1},
+		&fakeSolver{day: 2, parseErr: fmt.Errorf("bad input")},
+		&fakeSolver{day: 3, star2Panic: true},
+		&fakeSolver{day: 4},
+	}
+	// Act
+	err = runAll(h, solvers, 2)
+	// Assert
+	assert.Equal(t, ErrRunsFailed{Count: 3}, err)
+	lines := strings.Split(strings.TrimSpace(s.BufInOut.String()), "\n")
+	assert.Len(t, lines, 9)
+	expected := []string{"1 1 1", "1 2 10", "2 1 -", "2 2 -", "3 1 3", "3 2 -", "4 1 4", "4 2 40"}
+	for i, e := range expected {
+		fields := strings.Fields(lines[i+1])
+		assert.Equal(t, e, strings.Join(fields[:3], " "))
+	}
+	assert.Contains(t, lines[3], "error: bad input")
+	assert.Contains(t, lines[6], "error: panic: boom")
+}
+
+// TestRunAllInput is a test for run-all rejecting the input of a single day
+func TestRunAllInput(t *testing.T) {
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{name: "input", args: []string{"run-all", "--input", "-"}},
+		{name: "example", args: []string{"run-all", "--example", "1"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			s := test.NewTestStreams()
+			h, err := common.NewHelpers(s.Streams, test.NewTestViper(t), test.NewTestSlog(s.Streams))
+			assert.Nil(t, err)
+			s.BufIn.WriteString("3 4\n4 3\n")
+			rootCmd, err := NewRootCmd(h)
+			assert.Nil(t, err)
+			rootCmd.SetArgs(tc.args)
+			// Act
+			err = rootCmd.Execute()
+			// Assert
+			assert.Equal(t, ErrRunAllInput{}, err)
+			assert.NotContains(t, s.BufInOut.String(), "DAY")
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// daysFile is the file, relative to the repo root, where days are registered
+	daysFile = "cmd/days.go"
+	// solversMarker is the line in the days file that opens the list of solvers
+	solversMarker = "solvers := []common.Solver{"
+)
+
+//go:embed templates/*
+var templates embed.FS
+
+// scaffoldData is the data the scaffold templates are rendered with
+type scaffoldData struct {
+	Module     string
+	Day        int
+	Title      string
+	Model      string
+	ModelLower string
+	Star       int
+	Ordinal    string
+}
+
+// scaffoldFile is a file rendered from a template
+type scaffoldFile struct {
+	Template string
+	Path     string
+	Star     int
+}
+
+// ErrDayExists is an error that is returned when the day being scaffolded already exists
+type ErrDayExists struct {
+	Path string
+}
+
+// Error returns the error message
+func (e ErrDayExists) Error() string {
+	return fmt.Sprintf("%s already exists", e.Path)
+}
+
+// ErrCantRegister is an error that is returned when the days file doesn't look the way the scaffold expects
+type ErrCantRegister struct {
+	Reason string
+}
+
+// Error returns the error message
+func (e ErrCantRegister) Error() string {
+	return fmt.Sprintf("can't register the day in %s, %s, register it by hand", daysFile, e.Reason)
+}
+
+// newScaffoldCmd creates a new scaffold command
+func newScaffoldCmd(h *common.Helpers) *cobra.Command {
+	var day int
+	var root, title, model string
+	scaffoldCmd := &cobra.Command{
+		Use:   "scaffold",
+		Short: "Generate a new day",
+		Long:  "Generate the package, resources and registration for a new day from templates",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return scaffold(h, root, day, title, model)
+		},
+	}
+	scaffoldCmd.Flags().IntVar(&day, "day", 0, "day to generate")
+	scaffoldCmd.Flags().StringVar(&root, "root", ".", "root of the repo")
+	scaffoldCmd.Flags().StringVar(&title, "title", "", "title of the day's puzzle")
+	scaffoldCmd.Flags().StringVar(&model, "model", "Puzzle", "name of the type the input is parsed into")
+	_ = scaffoldCmd.MarkFlagRequired("day")
+	return scaffoldCmd
+}
+
+// scaffold generates a new day under a repo root
+func scaffold(h *common.Helpers, root string, day int, title string, model string) error {
+	if day < 1 || day > 25 {
+		return fmt.Errorf("day must be between 1 and 25, got %d", day)
+	}
+	if model == "" || !unicode.IsUpper([]rune(model)[0]) {
+		return fmt.Errorf("model must be an exported type name, got %q", model)
+	}
+	module, err := readModule(root)
+	if err != nil {
+		h.Logger.Error("Error reading go.mod", common.ErrAttr, err)
+		return err
+	}
+	info := common.SolverInfo{Day: day, Title: title}
+	if title == "" {
+		info.Title = info.Human()
+	}
+	pkgDir := filepath.Join(root, "cmd", info.Use())
+	if _, err := os.Stat(pkgDir); err == nil {
+		return ErrDayExists{Path: pkgDir}
+	}
+	data := scaffoldData{
+		Module:     module,
+		Day:        day,
+		Title:      info.Title,
+		Model:      model,
+		ModelLower: strings.ToLower(model),
+	}
+	resourceDir := filepath.Join(root, "common", "resources")
+	files := []scaffoldFile{
+		{Template: "cmd.go.tmpl", Path: filepath.Join(pkgDir, "cmd.go")},
+		{Template: "star.go.tmpl", Path: filepath.Join(pkgDir, "star1.go"), Star: 1},
+		{Template: "star.go.tmpl", Path: filepath.Join(pkgDir, "star2.go"), Star: 2},
+		{Template: "model.go.tmpl", Path: filepath.Join(pkgDir, data.ModelLower+".go")},
+		{Template: "model_test.go.tmpl", Path: filepath.Join(pkgDir, data.ModelLower+"_test.go")},
+		{Template: "examples.json.tmpl", Path: filepath.Join(resourceDir, info.ExamplesName())},
+	}
+
+	err = os.MkdirAll(pkgDir, 0o755)
+	if err != nil {
+		return err
+	}
+	// created are the files written so far, a failure removes them so no half-generated day stops the tree building
+	created := make([]string, 0, len(files)+2)
+	cleanup := func() {
+		for _, path := range created {
+			err := os.Remove(path)
+			if err != nil {
+				h.Logger.Error("Error removing file", "file", path, common.ErrAttr, err)
+			}
+		}
+		// the package dir didn't exist, so it's empty again
+		_ = os.Remove(pkgDir)
+	}
+	for _, f := range files {
+		data.Star = f.Star
+		data.Ordinal = map[int]string{1: "first", 2: "second"}[f.Star]
+		err = renderTemplate(f.Template, f.Path, data)
+		if err != nil {
+			h.Logger.Error("Error rendering file", "file", f.Path, common.ErrAttr, err)
+			cleanup()
+			return err
+		}
+		created = append(created, f.Path)
+		h.Logger.Info("Created file", "file", f.Path)
+	}
+	// empty placeholders, the real input comes from inputs fetch and the example from the puzzle
+	for _, name := range []string{info.ResourceName(), info.ExampleName(1)} {
+		path := filepath.Join(resourceDir, name)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		err = os.WriteFile(path, nil, 0o644)
+		if err != nil {
+			h.Logger.Error("Error writing file", "file", path, common.ErrAttr, err)
+			cleanup()
+			return err
+		}
+		created = append(created, path)
+		h.Logger.Info("Created file", "file", path)
+	}
+
+	err = registerDay(filepath.Join(root, daysFile), module, info)
+	if err != nil {
+		h.Logger.Error("Error registering day", common.ErrAttr, err)
+		return err
+	}
+	_, err = fmt.Fprintf(h.Streams.Out, "%s scaffolded in %s\n", info.Human(), pkgDir)
+	return err
+}
+
+// readModule returns the module path from the go.mod in the repo root
+func readModule(root string) (string, error) {
+	f, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.TrimSpace(module), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", errors.New("no module line in go.mod")
+}
+
+// renderTemplate renders a template to a path, go files are formatted
+func renderTemplate(name string, path string, data scaffoldData) error {
+	t, err := template.ParseFS(templates, fmt.Sprintf("templates/%s", name))
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	err = t.Execute(&buf, data)
+	if err != nil {
+		return err
+	}
+	contents := buf.Bytes()
+	if strings.HasSuffix(path, ".go") {
+		contents, err = format.Source(contents)
+		if err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, contents, 0o644)
+}
+
+// registerDay adds the import and solver of a day to the days file
+func registerDay(path string, module string, info common.SolverInfo) error {
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrCantRegister{Reason: "it doesn't exist"}
+	}
+	if err != nil {
+		return err
+	}
+	src := string(contents)
+	importLine := fmt.Sprintf("\t%q\n", fmt.Sprintf("%s/common", module))
+	if !strings.Contains(src, importLine) {
+		return ErrCantRegister{Reason: "the common import is missing"}
+	}
+	src = strings.Replace(src, importLine, fmt.Sprintf("\t%q\n%s", fmt.Sprintf("%s/cmd/%s", module, info.Use()), importLine), 1)
+
+	start := strings.Index(src, solversMarker)
+	if start < 0 {
+		return ErrCantRegister{Reason: "the list of solvers is missing"}
+	}
+	end := strings.Index(src[start:], "\n\t}\n")
+	if end < 0 {
+		return ErrCantRegister{Reason: "the list of solvers isn't closed"}
+	}
+	end += start
+	src = fmt.Sprintf("%s\n\t\t%s.NewSolver(),%s", src[:end], info.Use(), src[end:])
+
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, formatted, 0o644)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestScaffold is a test for the scaffold function
+func TestScaffold(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	days, err := os.ReadFile("days.go")
+	assert.Nil(t, err)
+	goMod, err := os.ReadFile(filepath.Join("..", "go.mod"))
+	assert.Nil(t, err)
+	goSum, err := os.ReadFile(filepath.Join("..", "go.sum"))
+	assert.Nil(t, err)
+	assert.Nil(t, os.MkdirAll(filepath.Join(root, "cmd"), 0o755))
+	// the generated package is built against a copy of common
+	assert.Nil(t, os.CopyFS(filepath.Join(root, "common"), os.DirFS(filepath.Join("..", "common"))))
+	assert.Nil(t, os.WriteFile(filepath.Join(root, "go.mod"), goMod, 0o644))
+	assert.Nil(t, os.WriteFile(filepath.Join(root, "go.sum"), goSum, 0o644))
+	assert.Nil(t, os.WriteFile(filepath.Join(root, daysFile), days, 0o644))
+	s := test.NewTestStreams()
+	l := test.NewTestSlog(s.Streams)
+	v := test.NewTestViper(t)
+	h, err := common.NewHelpers(s.Streams, v, l)
+	if err != nil {
+		l.Error(err.Error())
+		t.Log(err)
+		t.Fail()
+	}
+	// Act
+	err = scaffold(h, root, 5, `Print "Queue" \`, "Rules")
+	// Assert
+	assert.Nil(t, err)
+	for _, path := range []string{
+		"cmd/day5/cmd.go",
+		"cmd/day5/star1.go",
+		"cmd/day5/star2.go",
+		"cmd/day5/rules.go",
+		"cmd/day5/rules_test.go",
+		"common/resources/day5-star1",
+		"common/resources/day5-example1",
+		"common/resources/day5-examples.json",
+	} {
+		assert.FileExists(t, filepath.Join(root, path))
+	}
+	contents, err := os.ReadFile(filepath.Join(root, "cmd", "day5", "cmd.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(contents), `title = "Print \"Queue\" \\"`)
+	goTest := exec.Command("go", "test", "./cmd/day5")
+	goTest.Dir = root
+	out, err := goTest.CombinedOutput()
+	assert.Nil(t, err, string(out))
+	registered, err := os.ReadFile(filepath.Join(root, daysFile))
+	assert.Nil(t, err)
+	assert.Contains(t, string(registered), `"github.com/mrlunchbox777/2024-advent-of-code/cmd/day5"`)
+	assert.Contains(t, string(registered), "\t\tday5.NewSolver(),\n")
+	// Act
+	err = scaffold(h, root, 5, `Print "Queue" \`, "Rules")
+	// Assert
+	assert.Equal(t, ErrDayExists{Path: filepath.Join(root, "cmd", "day5")}, err)
+}
+
+// TestScaffoldCleanup is a test for the scaffold function removing the files it wrote when a later one fails
+func TestScaffoldCleanup(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	goMod, err := os.ReadFile(filepath.Join("..", "go.mod"))
+	assert.Nil(t, err)
+	assert.Nil(t, os.MkdirAll(filepath.Join(root, "cmd"), 0o755))
+	assert.Nil(t, os.WriteFile(filepath.Join(root, "go.mod"), goMod, 0o644))
+	s := test.NewTestStreams()
+	l := test.NewTestSlog(s.Streams)
+	h, err := common.NewHelpers(s.Streams, test.NewTestViper(t), l)
+	assert.Nil(t, err)
+	// Act
+	// there's no common/resources dir, so the examples manifest after the go files can't be written
+	err = scaffold(h, root, 5, "Print Queue", "Rules")
+	// Assert
+	assert.NotNil(t, err)
+	assert.NoDirExists(t, filepath.Join(root, "cmd", "day5"))
+}
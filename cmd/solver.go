@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/spf13/cobra"
+)
+
+// newDayCmd creates a new day command from its solver
+func newDayCmd(h *common.Helpers, s common.Solver) (*cobra.Command, error) {
+	info := s.Info()
+	dayCmd := &cobra.Command{
+		Use:   info.Use(),
+		Short: info.Human(),
+		Long:  fmt.Sprintf("%s: %s", info.Human(), info.Title),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("No subcommand given")
+		},
+	}
+
+	for _, star := range common.Stars() {
+		dayCmd.AddCommand(newStarCmd(h, s, star))
+	}
+	if c, ok := s.(common.Commander); ok {
+		dayCmd.AddCommand(c.Commands(h)...)
+	}
+	if f, ok := s.(common.Flagger); ok {
+		err := f.AddFlags(h, dayCmd.PersistentFlags())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dayCmd, nil
+}
+
+// newStarCmd creates a new star command for a solver
+func newStarCmd(h *common.Helpers, s common.Solver, star common.Star) *cobra.Command {
+	starCmd := &cobra.Command{
+		Use:   star.Use(),
+		Short: star.Use(),
+		Long:  star.Use(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStar(h, s, star)
+		},
+	}
+	return starCmd
+}
+
+// runStar parses the input for a solver and writes the result of a star
+func runStar(h *common.Helpers, s common.Solver, star common.Star) error {
+	res := runStars(h, s, []common.Star{star})[0]
+	err := h.WriteResults(common.TextOutput, res)
+	if err != nil {
+		return err
+	}
+	return res.Err
+}
+
+// runStars parses a solver's input once and runs the stars, failures are kept in the results
+func runStars(h *common.Helpers, s common.Solver, stars []common.Star) (results []*common.Result) {
+	info := s.Info()
+	h = h.With(common.DayAttr, info.Day)
+	for _, star := range stars {
+		results = append(results, &common.Result{Day: info.Day, Star: star})
+	}
+	// completed counts the stars that ran, failures only mark the ones that didn't
+	completed := 0
+	fail := func(err error) {
+		for _, res := range results[completed:] {
+			res.SetErr(err)
+		}
+	}
+	// a panicking solver shouldn't take the other days down with it
+	defer func() {
+		if r := recover(); r != nil {
+			h.Logger.Error("Solver panicked", "panic", r)
+			fail(fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	h.Logger.Info("Solving", "stars", len(stars))
+	start := time.Now()
+	f, in, err := loadInput(h.With(common.PhaseAttr, common.ParsePhase), s)
+	parseDuration := time.Since(start)
+	h.Logger.Debug("Parsed input", "duration", parseDuration)
+	if err != nil {
+		fail(err)
+		return results
+	}
+	for _, res := range results {
+		res.SetInput(f)
+		res.ParseDuration = parseDuration
+		sh := h.With(common.StarAttr, int(res.Star), common.PhaseAttr, common.SolvePhase)
+		start = time.Now()
+		answer, err := common.Solve(sh, s, res.Star, in)
+		res.Duration = time.Since(start)
+		res.Answer = answer
+		if err != nil {
+			sh.Logger.Error("Error solving", common.ErrAttr, err)
+			res.SetErr(err)
+		} else {
+			sh.Logger.Debug("Solved", "duration", res.Duration)
+		}
+		completed++
+	}
+	return results
+}
+
+// loadInput gets the input for a solver and parses it
+func loadInput(h *common.Helpers, s common.Solver) (*common.File, any, error) {
+	f, err := common.GetInput(h, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	in, err := s.Parse(h, f)
+	if err != nil {
+		h.Logger.Error("Error parsing input", common.ErrAttr, err)
+		return nil, nil, err
+	}
+	return f, in, nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/aoc"
+	"github.com/spf13/cobra"
+)
+
+// newSubmitCmd creates a new submit command
+func newSubmitCmd(h *common.Helpers, r *common.Registry) *cobra.Command {
+	var day, star int
+	submitCmd := &cobra.Command{
+		Use:   "submit",
+		Short: "Solve a star and submit the answer",
+		Long:  "Solve a star and submit the answer, answers already known to be wrong or out of bounds are not sent",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := r.Get(day)
+			if err != nil {
+				return err
+			}
+			return submit(h, s, common.Star(star))
+		},
+	}
+	submitCmd.Flags().IntVar(&day, "day", 0, "day to submit")
+	submitCmd.Flags().IntVar(&star, "star", 0, "star to submit")
+	_ = submitCmd.MarkFlagRequired("day")
+	_ = submitCmd.MarkFlagRequired("star")
+	return submitCmd
+}
+
+// submit solves a star, submits the answer and records the verdict
+func submit(h *common.Helpers, s common.Solver, star common.Star) error {
+	info := s.Info()
+	res := runStars(h, s, []common.Star{star})[0]
+	if res.Err != nil {
+		return res.Err
+	}
+	answer := res.Answer
+	c, err := aoc.NewClient(h)
+	if err != nil {
+		h.Logger.Error("Error creating client", common.ErrAttr, err)
+		return err
+	}
+	v, err := aoc.LoadVerdicts(c.CacheDir)
+	if err != nil {
+		h.Logger.Error("Error loading verdicts", common.ErrAttr, err)
+		return err
+	}
+	err = v.Check(info.Day, star, answer, time.Now())
+	if err != nil {
+		return err
+	}
+	sub, err := c.SubmitAnswer(h, info.Day, star, answer)
+	if err != nil {
+		return err
+	}
+	h.Logger.Debug("Response", "message", sub.Message)
+	v.Add(sub)
+	err = v.Save()
+	if err != nil {
+		h.Logger.Error("Error saving verdicts", common.ErrAttr, err)
+		return err
+	}
+	if sub.Verdict == aoc.Correct {
+		err = recordAnswer(h, info.Day, star, res.InputHash, answer)
+		if err != nil {
+			return err
+		}
+	}
+	line := fmt.Sprintf("%s %s: %d (%s)", info.Human(), star.Human(), answer, sub.Verdict)
+	if sub.Wait > 0 {
+		line = fmt.Sprintf("%s, wait %s", line, sub.Wait)
+	}
+	_, err = h.Streams.Out.Write([]byte(line + "\n"))
+	return err
+}
+
+// recordAnswer records an accepted answer in the ledger
+func recordAnswer(h *common.Helpers, day int, star common.Star, inputHash string, answer int) error {
+	l, err := common.LoadLedger(common.LedgerPath(h))
+	if err != nil {
+		h.Logger.Error("Error loading ledger", common.ErrAttr, err)
+		return err
+	}
+	l.Record(&common.LedgerEntry{
+		Day:       day,
+		Star:      star,
+		InputHash: inputHash,
+		Answer:    answer,
+	})
+	err = l.Save()
+	if err != nil {
+		h.Logger.Error("Error saving ledger", common.ErrAttr, err)
+	}
+	return err
+}
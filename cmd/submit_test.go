@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/aoc"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSubmit is a test for the submit command against a stub server, from solving the star to recording the verdict,
+// the answer in the ledger when it's correct, and refusing to send it again
+func TestSubmit(t *testing.T) {
+	testCases := []struct {
+		name            string
+		body            string
+		expectedVerdict aoc.Verdict
+		expectedOut     string
+		expectedLedger  bool
+	}{
+		{
+			name:            "correct",
+			body:            `<article><p>That's the right answer! You are <span class="day-success">one gold star</span> closer.</p></article>`,
+			expectedVerdict: aoc.Correct,
+			expectedOut:     "Day 1 Star 1: 1 (correct)\n",
+			expectedLedger:  true,
+		},
+		{
+			name:            "too_high",
+			body:            `<article><p>That's not the right answer; your answer is too high. Please wait one minute before trying again.</p></article>`,
+			expectedVerdict: aoc.TooHigh,
+			expectedOut:     "Day 1 Star 1: 1 (too high)\n",
+		},
+		{
+			name:            "rate_limited",
+			body:            `<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 5s left to wait.</p></article>`,
+			expectedVerdict: aoc.RateLimited,
+			expectedOut:     "Day 1 Star 1: 1 (rate limited), wait 1m5s\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			requests := 0
+			server := test.NewTestAOCServer(t, aoc.UserAgent, &requests, map[string]string{"1": tc.body})
+			defer server.Close()
+			inputDir := t.TempDir()
+			assert.Nil(t, os.WriteFile(filepath.Join(inputDir, "day1-star1"), []byte("1 2\n"), 0o644))
+			ledger := filepath.Join(t.TempDir(), "answers.json")
+			s := test.NewTestStreams()
+			l := test.NewTestSlog(s.Streams)
+			v := test.NewTestViper(t)
+			v.Set(aoc.URLKey, server.URL)
+			v.Set(aoc.SessionKey, test.TestSession)
+			v.Set(aoc.ThrottleKey, 0)
+			v.Set(common.InputDirsKey, []string{inputDir})
+			v.Set(common.LedgerKey, ledger)
+			h, err := common.NewHelpers(s.Streams, v, l)
+			if err != nil {
+				l.Error(err.Error())
+				t.Log(err)
+				t.Fail()
+			}
+			args := []string{"submit", "--day", "1", "--star", "1"}
+			rootCmd, err := NewRootCmd(h)
+			assert.Nil(t, err)
+			rootCmd.SetArgs(args)
+			// Act
+			err = rootCmd.Execute()
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedOut, s.BufInOut.String())
+			assert.Equal(t, 1, requests)
+			cacheDir, err := common.CacheDir(v)
+			assert.Nil(t, err)
+			verdicts, err := aoc.LoadVerdicts(cacheDir)
+			assert.Nil(t, err)
+			if assert.Len(t, verdicts.Submissions, 1) {
+				assert.Equal(t, 1, verdicts.Submissions[0].Answer)
+				assert.Equal(t, tc.expectedVerdict, verdicts.Submissions[0].Verdict)
+			}
+			recorded, err := common.LoadLedger(ledger)
+			assert.Nil(t, err)
+			entry := recorded.Lookup(1, common.Star1, (&common.File{Contents: []byte("1 2\n")}).Hash())
+			if tc.expectedLedger {
+				assert.NotNil(t, entry)
+			} else {
+				assert.Nil(t, entry)
+			}
+			// Act
+			rootCmd, err = NewRootCmd(h)
+			assert.Nil(t, err)
+			rootCmd.SetArgs(args)
+			err = rootCmd.Execute()
+			// Assert
+			assert.IsType(t, aoc.ErrKnownAnswer{}, err)
+			assert.Equal(t, 1, requests)
+		})
+	}
+}
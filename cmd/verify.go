@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/spf13/cobra"
+)
+
+// ErrAnswersDrifted is an error that is returned when solved stars no longer give their accepted answer
+type ErrAnswersDrifted struct {
+	Count int
+}
+
+// Error returns the error message
+func (e ErrAnswersDrifted) Error() string {
+	return fmt.Sprintf("%d answer(s) drifted from the ledger", e.Count)
+}
+
+// ErrVerifyInput is an error that is returned when verify is given an input path or example, only each day's own
+// input has accepted answers
+type ErrVerifyInput struct{}
+
+// Error returns the error message
+func (e ErrVerifyInput) Error() string {
+	return fmt.Sprintf("verify reads each day's own input, %s and %s can't be given", common.InputKey, common.ExampleKey)
+}
+
+// newVerifyCmd creates a new verify command
+func newVerifyCmd(h *common.Helpers, r *common.Registry) *cobra.Command {
+	var record bool
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Check every star still gives its accepted answer",
+		Long:  "Re-run every registered day and star against its input and compare the answers with the ledger",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if inputGiven(h) {
+				return ErrVerifyInput{}
+			}
+			return verify(h, r, record)
+		},
+	}
+	verifyCmd.Flags().BoolVar(&record, "record", false, "record the answers of stars missing from the ledger")
+	return verifyCmd
+}
+
+// verify compares the answer of every star with the ledger, a star that fails is reported and the others still run
+func verify(h *common.Helpers, r *common.Registry, record bool) error {
+	l, err := common.LoadLedger(common.LedgerPath(h))
+	if err != nil {
+		h.Logger.Error("Error loading ledger", common.ErrAttr, err)
+		return err
+	}
+	var out strings.Builder
+	drifted, failed := 0, 0
+	for _, s := range r.Solvers() {
+		info := s.Info()
+		for _, res := range runStars(h, s, common.Stars()) {
+			name := fmt.Sprintf("%s %s", info.Use(), res.Star.Use())
+			if res.Err != nil {
+				failed++
+				out.WriteString(fmt.Sprintf("%s: failed: %s\n", name, res.Err))
+				continue
+			}
+			hash := res.InputHash
+			e := l.Lookup(info.Day, res.Star, hash)
+			switch {
+			case e == nil && record:
+				l.Record(&common.LedgerEntry{Day: info.Day, Star: res.Star, InputHash: hash, Answer: res.Answer})
+				out.WriteString(fmt.Sprintf("%s: recorded %d\n", name, res.Answer))
+			case e == nil:
+				out.WriteString(fmt.Sprintf("%s: unrecorded %d (input %s)\n", name, res.Answer, hash[:12]))
+			case e.Answer != res.Answer:
+				drifted++
+				out.WriteString(fmt.Sprintf("%s: drifted (input %s)\n  - %d\n  + %d\n", name, hash[:12], e.Answer, res.Answer))
+			default:
+				out.WriteString(fmt.Sprintf("%s: ok %d\n", name, res.Answer))
+			}
+		}
+	}
+	_, err = h.Streams.Out.Write([]byte(out.String()))
+	if err != nil {
+		return err
+	}
+	if record {
+		err = l.Save()
+		if err != nil {
+			h.Logger.Error("Error saving ledger", common.ErrAttr, err)
+			return err
+		}
+	}
+	errs := make([]error, 0)
+	if drifted > 0 {
+		errs = append(errs, ErrAnswersDrifted{Count: drifted})
+	}
+	if failed > 0 {
+		errs = append(errs, ErrRunsFailed{Count: failed})
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVerify is a test for the verify command, it also guards the accepted answers in the repo ledger
+func TestVerify(t *testing.T) {
+	drifted := filepath.Join(t.TempDir(), "answers.json")
+	contents := `{"version": 1, "answers": [{"day": 1, "star": 1, "inputHash": "956a75883d22894a29a771f694d2886f67778ac0fede1b9f548e68520a0f701c", "answer": 1}]}`
+	assert.Nil(t, os.WriteFile(drifted, []byte(contents), 0o644))
+	// emptyInputs has an empty day 1 input, so day 1 fails and the other days are still checked
+	emptyInputs := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(emptyInputs, "day1-star1"), []byte(" \n"), 0o644))
+	testCases := []struct {
+		name        string
+		ledger      string
+		inputDirs   []string
+		args        []string
+		expectedErr error
+		expectedOut string
+	}{
+		{
+			name:   "repo_ledger",
+			ledger: filepath.Join("..", common.DefaultLedger),
+		},
+		{
+			name:        "drifted",
+			ledger:      drifted,
+			expectedErr: errors.Join(ErrAnswersDrifted{Count: 1}),
+			expectedOut: "day1 star1: drifted (input 956a75883d22)\n  - 1\n  + 2344935\n",
+		},
+		{
+			name:        "failed_day",
+			ledger:      filepath.Join("..", common.DefaultLedger),
+			inputDirs:   []string{emptyInputs},
+			expectedErr: errors.Join(ErrRunsFailed{Count: 2}),
+			expectedOut: "day1 star2: failed: input day1-star1 is empty\nday2 star1: ok",
+		},
+		{
+			name:        "record_input",
+			ledger:      filepath.Join(t.TempDir(), "answers.json"),
+			args:        []string{"--record", "--input", "-"},
+			expectedErr: ErrVerifyInput{},
+		},
+		{
+			name:        "record_example",
+			ledger:      filepath.Join(t.TempDir(), "answers.json"),
+			args:        []string{"--record", "--example", "1"},
+			expectedErr: ErrVerifyInput{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			s := test.NewTestStreams()
+			l := test.NewTestSlog(s.Streams)
+			v := test.NewTestViper(t)
+			if tc.inputDirs != nil {
+				v.Set(common.InputDirsKey, tc.inputDirs)
+			}
+			h, err := common.NewHelpers(s.Streams, v, l)
+			if err != nil {
+				l.Error(err.Error())
+				t.Log(err)
+				t.Fail()
+			}
+			rootCmd, err := NewRootCmd(h)
+			assert.Nil(t, err)
+			rootCmd.SetArgs(append([]string{"verify", "--ledger", tc.ledger}, tc.args...))
+			// Act
+			err = rootCmd.Execute()
+			// Assert
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Contains(t, s.BufInOut.String(), tc.expectedOut)
+			if tc.expectedErr == (ErrVerifyInput{}) {
+				assert.NoFileExists(t, tc.ledger)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package aoc
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+)
+
+const (
+	// URLKey is the viper key for the base url of the advent of code server
+	URLKey = "aoc-url"
+	// SessionKey is the viper key for the session cookie
+	SessionKey = "aoc-session"
+	// SessionFileKey is the viper key for a file holding the session cookie
+	SessionFileKey = "aoc-session-file"
+	// ThrottleKey is the viper key for the minimum time between requests
+	ThrottleKey = "aoc-throttle"
+	// DefaultURL is the base url of the advent of code server
+	DefaultURL = "https://adventofcode.com"
+	// DefaultThrottle is the minimum time between requests
+	DefaultThrottle = 5 * time.Second
+	// Year is the advent of code event
+	Year = 2024
+	// UserAgent identifies the tool to the server, as the advent of code automation guidelines ask
+	UserAgent = "github.com/mrlunchbox777/2024-advent-of-code"
+	// lastRequestFile is the file in the cache dir holding the time of the last request
+	lastRequestFile = ".last-request"
+)
+
+// Client is a struct that contains an advent of code http client
+type Client struct {
+	URL      string
+	Session  string
+	Throttle time.Duration
+	CacheDir string
+	HTTP     *http.Client
+}
+
+// ErrNoSession is an error that is returned when no session cookie is configured
+type ErrNoSession struct{}
+
+// Error returns the error message
+func (e ErrNoSession) Error() string {
+	return fmt.Sprintf("no session cookie, set %s or %s", SessionKey, SessionFileKey)
+}
+
+// ErrUnauthorized is an error that is returned when the server rejects the session cookie
+type ErrUnauthorized struct {
+	Status int
+}
+
+// Error returns the error message
+func (e ErrUnauthorized) Error() string {
+	return fmt.Sprintf("session rejected by the server (status %d), is it expired?", e.Status)
+}
+
+// ErrNotAvailable is an error that is returned when a puzzle isn't unlocked yet
+type ErrNotAvailable struct {
+	Day int
+}
+
+// Error returns the error message
+func (e ErrNotAvailable) Error() string {
+	return fmt.Sprintf("day %d is not available yet", e.Day)
+}
+
+// ErrStatus is an error that is returned for any other unexpected status
+type ErrStatus struct {
+	Status int
+	Body   string
+}
+
+// Error returns the error message
+func (e ErrStatus) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.Status, e.Body)
+}
+
+// NewClient creates a new Client struct from the viper configuration
+func NewClient(h *common.Helpers) (*Client, error) {
+	session, err := getSession(h)
+	if err != nil {
+		return nil, err
+	}
+	cacheDir, err := common.CacheDir(h.Viper)
+	if err != nil {
+		h.Logger.Error("Error getting cache dir", common.ErrAttr, err)
+		return nil, err
+	}
+	url := h.Viper.GetString(URLKey)
+	if url == "" {
+		url = DefaultURL
+	}
+	throttle := DefaultThrottle
+	if h.Viper.IsSet(ThrottleKey) {
+		throttle = h.Viper.GetDuration(ThrottleKey)
+	}
+	return &Client{
+		URL:      strings.TrimSuffix(url, "/"),
+		Session:  session,
+		Throttle: throttle,
+		CacheDir: cacheDir,
+		HTTP: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// getSession returns the session cookie from viper, or the session file
+func getSession(h *common.Helpers) (string, error) {
+	session := h.Viper.GetString(SessionKey)
+	if session != "" {
+		return session, nil
+	}
+	path := h.Viper.GetString(SessionFileKey)
+	if path == "" {
+		return "", ErrNoSession{}
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		h.Logger.Error("Error reading session file", "file", path, common.ErrAttr, err)
+		return "", err
+	}
+	session = strings.TrimSpace(string(contents))
+	if session == "" {
+		return "", ErrNoSession{}
+	}
+	return session, nil
+}
+
+// dayURL returns the url of a day's puzzle, with any extra path appended
+func (c *Client) dayURL(day int, path string) string {
+	return fmt.Sprintf("%s/%d/day/%d%s", c.URL, Year, day, path)
+}
+
+// do sends a request once the throttle allows, and returns the body
+func (c *Client) do(h *common.Helpers, req *http.Request, day int) ([]byte, error) {
+	c.wait(h)
+	req.Header.Set("User-Agent", UserAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	h.Logger.Debug("Sending request", "method", req.Method, "url", req.URL.String())
+	resp, err := c.HTTP.Do(req)
+	c.recordRequest(h)
+	if err != nil {
+		h.Logger.Error("Error sending request", common.ErrAttr, err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		h.Logger.Error("Error reading response", common.ErrAttr, err)
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, nil
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		return nil, ErrUnauthorized{Status: resp.StatusCode}
+	case http.StatusNotFound:
+		return nil, ErrNotAvailable{Day: day}
+	default:
+		return nil, ErrStatus{Status: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+	}
+}
+
+// wait sleeps until the throttle has passed since the last request, across runs of the tool
+func (c *Client) wait(h *common.Helpers) {
+	if c.Throttle <= 0 {
+		return
+	}
+	contents, err := os.ReadFile(filepath.Join(c.CacheDir, lastRequestFile))
+	if err != nil {
+		return
+	}
+	last, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(contents)))
+	if err != nil {
+		return
+	}
+	remaining := time.Until(last.Add(c.Throttle))
+	if remaining > 0 {
+		h.Logger.Info("Throttling request", "wait", remaining.Round(time.Millisecond))
+		time.Sleep(remaining)
+	}
+}
+
+// recordRequest records the time of the last request for the throttle
+func (c *Client) recordRequest(h *common.Helpers) {
+	err := os.MkdirAll(c.CacheDir, 0o755)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(c.CacheDir, lastRequestFile), []byte(time.Now().Format(time.RFC3339Nano)), 0o644)
+	}
+	if err != nil {
+		h.Logger.Warn("Error recording request time", common.ErrAttr, err)
+	}
+}
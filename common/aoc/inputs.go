@@ -0,0 +1,56 @@
+package aoc
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+)
+
+// FetchInput downloads the personal puzzle input for a day
+func (c *Client) FetchInput(h *common.Helpers, day int) ([]byte, error) {
+	h.Logger.Debug("Fetching input", common.DayAttr, day)
+	req, err := http.NewRequest(http.MethodGet, c.dayURL(day, "/input"), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(h, req, day)
+}
+
+// CacheInput downloads the input for a day into the cache dir and returns it, if a
+// layer other than the embedded resources already has the input it is returned instead
+func (c *Client) CacheInput(h *common.Helpers, day int) (*common.File, bool, error) {
+	name := common.SolverInfo{Day: day}.ResourceName()
+	f := h.Resources.GetFile(h, name)
+	if f != nil && f.Layer != common.EmbeddedLayer {
+		h.Logger.Info("Input already present, not downloading", "resource", name, "layer", f.Layer)
+		return f, false, nil
+	}
+	contents, err := c.FetchInput(h, day)
+	if err != nil {
+		h.Logger.Error("Error fetching input", common.ErrAttr, err)
+		return nil, false, err
+	}
+	err = os.MkdirAll(c.CacheDir, 0o755)
+	if err != nil {
+		h.Logger.Error("Error creating cache dir", common.ErrAttr, err)
+		return nil, false, err
+	}
+	path := filepath.Join(c.CacheDir, name)
+	err = os.WriteFile(path, contents, 0o644)
+	if err != nil {
+		h.Logger.Error("Error writing input", common.ErrAttr, err)
+		return nil, false, err
+	}
+	// pick up the new file through the layers
+	err = h.LoadResources()
+	if err != nil {
+		return nil, false, err
+	}
+	f = h.Resources.GetFile(h, name)
+	if f == nil {
+		return nil, false, common.ErrResourceNotFound{Name: name}
+	}
+	return f, true, nil
+}
@@ -0,0 +1,94 @@
+package aoc
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestHelpers creates helpers pointed at a stub server and a temporary cache dir
+func newTestHelpers(t *testing.T, url string, session string) *common.Helpers {
+	s := test.NewTestStreams()
+	l := test.NewTestSlog(s.Streams)
+	v := test.NewTestViper(t)
+	v.Set(URLKey, url)
+	v.Set(SessionKey, session)
+	v.Set(ThrottleKey, time.Duration(0))
+	h, err := common.NewHelpers(s.Streams, v, l)
+	if err != nil {
+		l.Error(err.Error())
+		t.Log(err)
+		t.Fail()
+	}
+	return h
+}
+
+// TestCacheInput is a test for the CacheInput function
+func TestCacheInput(t *testing.T) {
+	testCases := []struct {
+		name             string
+		session          string
+		day              int
+		times            int
+		expectedRequests int
+		expectedErr      error
+	}{
+		{
+			name:             "fetches_once",
+			session:          test.TestSession,
+			day:              5,
+			times:            2,
+			expectedRequests: 1,
+		},
+		{
+			name:             "bad_session",
+			session:          "bad-session",
+			day:              5,
+			times:            1,
+			expectedRequests: 1,
+			expectedErr:      ErrUnauthorized{Status: http.StatusBadRequest},
+		},
+		{
+			name:             "not_available",
+			session:          test.TestSession,
+			day:              25,
+			times:            1,
+			expectedRequests: 1,
+			expectedErr:      ErrNotAvailable{Day: 25},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			requests := 0
+			server := test.NewTestAOCServer(t, UserAgent, &requests, nil)
+			defer server.Close()
+			h := newTestHelpers(t, server.URL, tc.session)
+			c, err := NewClient(h)
+			assert.Nil(t, err)
+			for i := 0; i < tc.times; i++ {
+				// Act
+				f, fetched, err := c.CacheInput(h, tc.day)
+				// Assert
+				if tc.expectedErr != nil {
+					assert.Equal(t, tc.expectedErr, err)
+					continue
+				}
+				assert.Nil(t, err)
+				assert.Equal(t, i == 0, fetched)
+				assert.Equal(t, c.CacheDir, f.Layer)
+				assert.Equal(t, "47|53\n", string(f.Contents))
+				contents, err := os.ReadFile(filepath.Join(c.CacheDir, "day5-star1"))
+				assert.Nil(t, err)
+				assert.Equal(t, "47|53\n", string(contents))
+			}
+			assert.Equal(t, tc.expectedRequests, requests)
+		})
+	}
+}
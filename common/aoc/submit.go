@@ -0,0 +1,133 @@
+package aoc
+
+import (
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+)
+
+// Verdict is the server's judgement of a submitted answer
+type Verdict string
+
+const (
+	// Correct is the verdict for the right answer
+	Correct = Verdict("correct")
+	// TooHigh is the verdict for a wrong answer above the right one
+	TooHigh = Verdict("too high")
+	// TooLow is the verdict for a wrong answer below the right one
+	TooLow = Verdict("too low")
+	// Wrong is the verdict for a wrong answer without a hint
+	Wrong = Verdict("wrong")
+	// RateLimited is the verdict when an answer was sent too soon after the last one
+	RateLimited = Verdict("rate limited")
+	// WrongLevel is the verdict when the star is already solved or isn't unlocked yet, the server doesn't say which
+	WrongLevel = Verdict("wrong level")
+	// Unknown is the verdict when the response couldn't be understood
+	Unknown = Verdict("unknown")
+)
+
+var (
+	// articleMatch matches the article holding the response message
+	articleMatch = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	// tagMatch matches any html tag
+	tagMatch = regexp.MustCompile(`<[^>]+>`)
+	// spaceMatch matches runs of whitespace
+	spaceMatch = regexp.MustCompile(`\s+`)
+	// waitMatch matches the wait time of a rate limited answer, e.g. You have 1m 5s left to wait
+	waitMatch = regexp.MustCompile(`You have (?:(\d+)m ?)?(?:(\d+)s )?left to wait`)
+)
+
+// Submission is a struct that contains a submitted answer and its verdict
+type Submission struct {
+	Day     int           `json:"day"`
+	Star    common.Star   `json:"star"`
+	Answer  int           `json:"answer"`
+	Verdict Verdict       `json:"verdict"`
+	Wait    time.Duration `json:"wait,omitempty"`
+	Message string        `json:"message,omitempty"`
+	Time    time.Time     `json:"time"`
+}
+
+// ParseVerdict parses the html response to a submitted answer into a verdict, the wait
+// time if rate limited, and the message shown to the user
+func ParseVerdict(body string) (Verdict, time.Duration, string) {
+	message := body
+	if m := articleMatch.FindStringSubmatch(body); m != nil {
+		message = m[1]
+	}
+	message = html.UnescapeString(tagMatch.ReplaceAllString(message, " "))
+	message = strings.TrimSpace(spaceMatch.ReplaceAllString(message, " "))
+
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		return Correct, 0, message
+	case strings.Contains(message, "You gave an answer too recently"):
+		return RateLimited, parseWait(message), message
+	case strings.Contains(message, "You don't seem to be solving the right level"):
+		return WrongLevel, 0, message
+	case strings.Contains(message, "That's not the right answer"):
+		switch {
+		case strings.Contains(message, "your answer is too high"):
+			return TooHigh, 0, message
+		case strings.Contains(message, "your answer is too low"):
+			return TooLow, 0, message
+		default:
+			return Wrong, 0, message
+		}
+	default:
+		return Unknown, 0, message
+	}
+}
+
+// parseWait parses the wait time out of a rate limited message
+func parseWait(message string) time.Duration {
+	m := waitMatch.FindStringSubmatch(message)
+	if m == nil {
+		return 0
+	}
+	var wait time.Duration
+	if m[1] != "" {
+		minutes, _ := strconv.Atoi(m[1])
+		wait += time.Duration(minutes) * time.Minute
+	}
+	if m[2] != "" {
+		seconds, _ := strconv.Atoi(m[2])
+		wait += time.Duration(seconds) * time.Second
+	}
+	return wait
+}
+
+// SubmitAnswer posts an answer for a day's star and returns the parsed verdict
+func (c *Client) SubmitAnswer(h *common.Helpers, day int, star common.Star, answer int) (*Submission, error) {
+	h.Logger.Debug("Submitting answer", common.DayAttr, day, common.StarAttr, int(star))
+	form := url.Values{
+		"level":  {strconv.Itoa(int(star))},
+		"answer": {strconv.Itoa(answer)},
+	}
+	req, err := http.NewRequest(http.MethodPost, c.dayURL(day, "/answer"), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	body, err := c.do(h, req, day)
+	if err != nil {
+		h.Logger.Error("Error submitting answer", common.ErrAttr, err)
+		return nil, err
+	}
+	verdict, wait, message := ParseVerdict(string(body))
+	return &Submission{
+		Day:     day,
+		Star:    star,
+		Answer:  answer,
+		Verdict: verdict,
+		Wait:    wait,
+		Message: message,
+		Time:    time.Now(),
+	}, nil
+}
@@ -0,0 +1,124 @@
+package aoc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseVerdict is a test for the ParseVerdict function
+func TestParseVerdict(t *testing.T) {
+	testCases := []struct {
+		name         string
+		body         string
+		expected     Verdict
+		expectedWait time.Duration
+	}{
+		{
+			name:     "correct",
+			body:     `<main><article><p>That's the right answer! You are <span class="day-success">one gold star</span> closer.</p></article></main>`,
+			expected: Correct,
+		},
+		{
+			name:     "too_high",
+			body:     `<article><p>That's not the right answer; your answer is too high. Please wait one minute before trying again.</p></article>`,
+			expected: TooHigh,
+		},
+		{
+			name:     "too_low",
+			body:     `<article><p>That's not the right answer; your answer is too low. Please wait one minute before trying again.</p></article>`,
+			expected: TooLow,
+		},
+		{
+			name:     "wrong",
+			body:     `<article><p>That's not the right answer. If you're stuck, make sure you're using the full input data.</p></article>`,
+			expected: Wrong,
+		},
+		{
+			name:         "rate_limited_minutes",
+			body:         `<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 5s left to wait. <a href="/2024/day/1">[Return to Day 1]</a></p></article>`,
+			expected:     RateLimited,
+			expectedWait: time.Minute + 5*time.Second,
+		},
+		{
+			name:         "rate_limited_seconds",
+			body:         `<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 39s left to wait.</p></article>`,
+			expected:     RateLimited,
+			expectedWait: 39 * time.Second,
+		},
+		{
+			name:     "wrong_level",
+			body:     `<article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article>`,
+			expected: WrongLevel,
+		},
+		{
+			name:     "unknown",
+			body:     `<html><body>Service Unavailable</body></html>`,
+			expected: Unknown,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			verdict, wait, message := ParseVerdict(tc.body)
+			// Assert
+			assert.Equal(t, tc.expected, verdict)
+			assert.Equal(t, tc.expectedWait, wait)
+			assert.NotContains(t, message, "<")
+		})
+	}
+}
+
+// TestVerdictsCheck is a test for the Check function
+func TestVerdictsCheck(t *testing.T) {
+	now := time.Date(2024, 12, 1, 5, 0, 0, 0, time.UTC)
+	// limited is when the server asked us to wait, the wait covers every day and star
+	limited := now.Add(-time.Hour)
+	v := &Verdicts{
+		Submissions: []*Submission{
+			{Day: 1, Star: common.Star1, Answer: 100, Verdict: TooHigh, Time: now},
+			{Day: 1, Star: common.Star1, Answer: 10, Verdict: TooLow, Time: now},
+			{Day: 1, Star: common.Star1, Answer: 50, Verdict: Wrong, Time: now},
+			{Day: 1, Star: common.Star2, Answer: 7, Verdict: Correct, Time: now},
+			{Day: 3, Star: common.Star2, Answer: 5, Verdict: WrongLevel, Time: now},
+			{Day: 2, Star: common.Star1, Answer: 3, Verdict: RateLimited, Wait: time.Minute, Time: limited},
+		},
+	}
+	testCases := []struct {
+		name     string
+		day      int
+		star     common.Star
+		answer   int
+		now      time.Time
+		expected bool
+	}{
+		{name: "inside_bounds", day: 1, star: common.Star1, answer: 42, now: now, expected: true},
+		{name: "known_wrong", day: 1, star: common.Star1, answer: 50, now: now},
+		{name: "too_high", day: 1, star: common.Star1, answer: 100, now: now},
+		{name: "above_too_high", day: 1, star: common.Star1, answer: 101, now: now},
+		{name: "below_too_low", day: 1, star: common.Star1, answer: 9, now: now},
+		{name: "already_correct", day: 1, star: common.Star2, answer: 7, now: now},
+		{name: "other_than_correct", day: 1, star: common.Star2, answer: 8, now: now},
+		{name: "rate_limited", day: 2, star: common.Star1, answer: 4, now: limited.Add(30 * time.Second)},
+		{name: "rate_limit_over", day: 2, star: common.Star1, answer: 4, now: limited.Add(2 * time.Minute), expected: true},
+		{name: "rate_limited_other_star", day: 2, star: common.Star2, answer: 4, now: limited.Add(30 * time.Second)},
+		{name: "rate_limited_other_day", day: 1, star: common.Star1, answer: 42, now: limited.Add(30 * time.Second)},
+		{name: "other_day", day: 3, star: common.Star1, answer: 100, now: now, expected: true},
+		// the star may not have been unlocked yet, so a wrong level doesn't block it
+		{name: "after_wrong_level", day: 3, star: common.Star2, answer: 5, now: now, expected: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			err := v.Check(tc.day, tc.star, tc.answer, tc.now)
+			// Assert
+			if tc.expected {
+				assert.Nil(t, err)
+			} else {
+				assert.IsType(t, ErrKnownAnswer{}, err)
+			}
+		})
+	}
+}
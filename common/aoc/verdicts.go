@@ -0,0 +1,117 @@
+package aoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+)
+
+const (
+	// verdictsFile is the file in the cache dir holding the submitted answers
+	verdictsFile = "verdicts.json"
+)
+
+// Verdicts is a struct that contains every submitted answer, stored in the cache dir
+type Verdicts struct {
+	Path        string        `json:"-"`
+	Submissions []*Submission `json:"submissions"`
+}
+
+// ErrKnownAnswer is an error that is returned when an answer doesn't need to be submitted
+type ErrKnownAnswer struct {
+	Answer int
+	Reason string
+}
+
+// Error returns the error message
+func (e ErrKnownAnswer) Error() string {
+	return fmt.Sprintf("not submitting %d: %s", e.Answer, e.Reason)
+}
+
+// LoadVerdicts loads the submitted answers from a directory, empty if there are none yet
+func LoadVerdicts(dir string) (*Verdicts, error) {
+	v := &Verdicts{
+		Path: filepath.Join(dir, verdictsFile),
+	}
+	contents, err := os.ReadFile(v.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return v, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(contents, v)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Save writes the submitted answers back to disk
+func (v *Verdicts) Save() error {
+	contents, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(v.Path), 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(v.Path, contents, 0o644)
+}
+
+// Add records a submission
+func (v *Verdicts) Add(s *Submission) {
+	v.Submissions = append(v.Submissions, s)
+}
+
+// Check returns an error if an answer is already known, outside the known bounds, or the server asked us to wait
+func (v *Verdicts) Check(day int, star common.Star, answer int, now time.Time) error {
+	// the server throttles the whole account, so a wait covers every day and star
+	for _, s := range v.Submissions {
+		if s.Verdict != RateLimited {
+			continue
+		}
+		if until := s.Time.Add(s.Wait); now.Before(until) {
+			return ErrKnownAnswer{Answer: answer, Reason: fmt.Sprintf("rate limited for another %s", until.Sub(now).Round(time.Second))}
+		}
+	}
+	var tooHigh, tooLow *int
+	for _, s := range v.Submissions {
+		if s.Day != day || s.Star != star {
+			continue
+		}
+		switch s.Verdict {
+		case Correct:
+			if s.Answer == answer {
+				return ErrKnownAnswer{Answer: answer, Reason: "already accepted as correct"}
+			}
+			return ErrKnownAnswer{Answer: answer, Reason: fmt.Sprintf("%d was already accepted as correct", s.Answer)}
+		case Wrong:
+			if s.Answer == answer {
+				return ErrKnownAnswer{Answer: answer, Reason: "already known to be wrong"}
+			}
+		case TooHigh:
+			if tooHigh == nil || s.Answer < *tooHigh {
+				tooHigh = &s.Answer
+			}
+		case TooLow:
+			if tooLow == nil || s.Answer > *tooLow {
+				tooLow = &s.Answer
+			}
+		}
+	}
+	if tooHigh != nil && answer >= *tooHigh {
+		return ErrKnownAnswer{Answer: answer, Reason: fmt.Sprintf("%d is already known to be too high", *tooHigh)}
+	}
+	if tooLow != nil && answer <= *tooLow {
+		return ErrKnownAnswer{Answer: answer, Reason: fmt.Sprintf("%d is already known to be too low", *tooLow)}
+	}
+	return nil
+}
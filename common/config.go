@@ -0,0 +1,109 @@
+package common
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// ConfigKey is the viper key for the path of the config file
+	ConfigKey = "config"
+	// configFileName is the name of the config file in the user's config directory
+	configFileName = "config.yaml"
+	// EnvPrefix is the prefix of the env variables settings are read from, so generic names like INPUT are left alone
+	EnvPrefix = "AOC2024"
+)
+
+// BindEnv reads every setting from its env variable, see EnvName
+func BindEnv(v *viper.Viper) {
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	v.AutomaticEnv()
+}
+
+// EnvName returns the env variable of a key, e.g. AOC2024_LOG_LEVEL for log-level
+func EnvName(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
+// ConfigPath returns the path of the config file and whether it was asked for explicitly,
+// otherwise it is $XDG_CONFIG_HOME/aoc2024/config.yaml
+func ConfigPath(v *viper.Viper) (string, bool, error) {
+	path := v.GetString(ConfigKey)
+	if path != "" {
+		return path, true, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", false, err
+	}
+	return filepath.Join(dir, appDirName, configFileName), false, nil
+}
+
+// configFile returns the path of the config file to read, empty if the default config file doesn't exist
+func configFile(v *viper.Viper) (string, error) {
+	path, explicit, err := ConfigPath(v)
+	if err != nil {
+		// without a config dir there is no default config file
+		return "", nil
+	}
+	_, err = os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return "", nil
+	}
+	return path, nil
+}
+
+// ReadConfigFile reads only the config file into a new viper instance, and returns its path,
+// the instance is empty if the default config file doesn't exist
+func ReadConfigFile(v *viper.Viper) (*viper.Viper, string, error) {
+	fileViper := viper.New()
+	path, err := configFile(v)
+	if err != nil || path == "" {
+		return fileViper, path, err
+	}
+	fileViper.SetConfigFile(path)
+	fileViper.SetConfigType("yaml")
+	err = fileViper.ReadInConfig()
+	if err != nil {
+		return nil, path, err
+	}
+	return fileViper, path, nil
+}
+
+// LoadConfig reads the config file into viper and rebuilds the logger and resources from it,
+// precedence is flag > env > file > default
+func (h *Helpers) LoadConfig() error {
+	path, err := configFile(h.Viper)
+	if err != nil {
+		return err
+	}
+	if path != "" {
+		h.Viper.SetConfigFile(path)
+		h.Viper.SetConfigType("yaml")
+		err = h.Viper.ReadInConfig()
+		if err != nil {
+			h.Logger.Error("Error reading config file", "file", path, ErrAttr, err)
+			return err
+		}
+	}
+	l, c, err := NewLogger(h.Streams.ErrOut, h.Viper)
+	if err != nil {
+		h.Logger.Error("Error configuring logger", ErrAttr, err)
+		return err
+	}
+	err = h.SetLogger(l, c)
+	if err != nil {
+		h.Logger.Error("Error closing log file", ErrAttr, err)
+		return err
+	}
+	if path != "" {
+		h.Logger.Debug("Loaded config file", "file", path)
+	}
+	return h.LoadResources()
+}
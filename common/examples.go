@@ -0,0 +1,79 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// ExampleKey is the viper key for the number of the example to use as input
+	ExampleKey = "example"
+)
+
+// Examples is a struct that contains a day's examples, read from the dayN-examples.json sidecar manifest
+type Examples struct {
+	Examples []*Example `json:"examples"`
+}
+
+// Example is a struct that contains an example input's number and expected answers,
+// the input itself is the dayN-exampleM resource
+type Example struct {
+	Number int  `json:"example"`
+	Star1  *int `json:"star1,omitempty"`
+	Star2  *int `json:"star2,omitempty"`
+}
+
+// ErrInputConflict is an error that is returned when both an input path and an example are given
+type ErrInputConflict struct{}
+
+// Error returns the error message
+func (e ErrInputConflict) Error() string {
+	return fmt.Sprintf("only one of %s and %s can be given", InputKey, ExampleKey)
+}
+
+// ExampleName returns the name of an example resource, e.g. day1-example1
+func (i SolverInfo) ExampleName(n int) string {
+	return fmt.Sprintf("%s-example%d", i.Use(), n)
+}
+
+// ExamplesName returns the name of the examples manifest resource, e.g. day1-examples.json
+func (i SolverInfo) ExamplesName() string {
+	return fmt.Sprintf("%s-examples.json", i.Use())
+}
+
+// Expected returns the expected answer of a star, false if the example doesn't cover it
+func (e *Example) Expected(star Star) (int, bool) {
+	var answer *int
+	switch star {
+	case Star1:
+		answer = e.Star1
+	case Star2:
+		answer = e.Star2
+	}
+	if answer == nil {
+		return 0, false
+	}
+	return *answer, true
+}
+
+// GetExamples returns the examples of a solver, empty if it has no manifest
+func GetExamples(h *Helpers, s Solver) ([]*Example, error) {
+	name := s.Info().ExamplesName()
+	f := h.Resources.GetFile(h, name)
+	if f == nil {
+		h.Logger.Debug("No examples manifest", "file", name)
+		return nil, nil
+	}
+	e := &Examples{}
+	err := json.Unmarshal(f.Contents, e)
+	if err != nil {
+		h.Logger.Error("Error reading examples manifest", "file", name, ErrAttr, err)
+		return nil, err
+	}
+	return e.Examples, nil
+}
+
+// GetExample returns the input file of an example
+func GetExample(h *Helpers, s Solver, n int) (*File, error) {
+	return getResource(h, s.Info().ExampleName(n))
+}
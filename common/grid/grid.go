@@ -0,0 +1,279 @@
+package grid
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common/parse"
+)
+
+// Grid is a rectangular grid of cells, stored row by row
+type Grid[T any] struct {
+	Width  int
+	Height int
+	Cells  []T
+}
+
+// ErrRagged is an error that is returned when rows of a grid have different widths
+type ErrRagged struct {
+	Row      int
+	Expected int
+	Actual   int
+}
+
+// Error returns the error message
+func (e ErrRagged) Error() string {
+	return fmt.Sprintf("row %d has %d cells, expected %d", e.Row, e.Actual, e.Expected)
+}
+
+// New creates a new grid of zero values
+func New[T any](width int, height int) *Grid[T] {
+	return &Grid[T]{
+		Width:  width,
+		Height: height,
+		Cells:  make([]T, width*height),
+	}
+}
+
+// FromRows creates a new grid from rows of cells, every row must be as wide as the first
+func FromRows[S ~[]T, T any](rows []S) (*Grid[T], error) {
+	if len(rows) == 0 {
+		return New[T](0, 0), nil
+	}
+	g := &Grid[T]{
+		Width:  len(rows[0]),
+		Height: len(rows),
+		Cells:  make([]T, 0, len(rows[0])*len(rows)),
+	}
+	for y, row := range rows {
+		if len(row) != g.Width {
+			return nil, ErrRagged{Row: y, Expected: g.Width, Actual: len(row)}
+		}
+		g.Cells = append(g.Cells, row...)
+	}
+	return g, nil
+}
+
+// Parse creates a new grid of runes from the non-blank lines of a resource
+func Parse(resource string, contents []byte) (*Grid[rune], error) {
+	return ParseFunc(resource, contents, func(r rune) (rune, error) {
+		return r, nil
+	})
+}
+
+// ParseFunc creates a new grid from the non-blank lines of a resource, converting each rune with f,
+// errors point at the line and column of the rune
+func ParseFunc[T any](resource string, contents []byte, f func(rune) (T, error)) (*Grid[T], error) {
+	return FromLines(resource, parse.Lines(resource, contents), f)
+}
+
+// FromLines creates a new grid from lines, e.g. a section of a resource, converting each rune with f,
+// errors point at the line and column of the rune
+func FromLines[T any](resource string, lines []parse.Line, f func(rune) (T, error)) (*Grid[T], error) {
+	return fromLines(resource, lines, func(text string) []rune { return []rune(text) }, f)
+}
+
+// ParseClusters creates a new grid from the non-blank lines of a resource, a cell per grapheme cluster (see
+// parse.Graphemes) converted with f, errors point at the line and column of the cluster
+func ParseClusters[T any](resource string, contents []byte, f func(string) (T, error)) (*Grid[T], error) {
+	return FromClusterLines(resource, parse.Lines(resource, contents), f)
+}
+
+// FromClusterLines creates a new grid from lines, a cell per grapheme cluster (see parse.Graphemes) converted with f,
+// errors point at the line and column of the cluster
+func FromClusterLines[T any](resource string, lines []parse.Line, f func(string) (T, error)) (*Grid[T], error) {
+	return fromLines(resource, lines, parse.Graphemes, f)
+}
+
+// fromLines creates a new grid from lines, splitting each into its cells with split and converting them with f
+func fromLines[C any, T any](resource string, lines []parse.Line, split func(string) []C, f func(C) (T, error)) (*Grid[T], error) {
+	if len(lines) == 0 {
+		return nil, parse.ErrParse{Resource: resource, Err: parse.ErrEmpty{}}
+	}
+	g := &Grid[T]{Height: len(lines)}
+	for y, l := range lines {
+		row := split(l.Text)
+		if y == 0 {
+			g.Width = len(row)
+			g.Cells = make([]T, 0, g.Width*g.Height)
+		}
+		if len(row) != g.Width {
+			return nil, l.Err(min(len(row), g.Width)+1, parse.ErrRagged{Expected: g.Width, Actual: len(row)})
+		}
+		for x, c := range row {
+			v, err := f(c)
+			if err != nil {
+				return nil, l.Err(x+1, err)
+			}
+			g.Cells = append(g.Cells, v)
+		}
+	}
+	return g, nil
+}
+
+// Size returns the width and height of the grid as a point
+func (g *Grid[T]) Size() Point {
+	return Point{X: g.Width, Y: g.Height}
+}
+
+// In returns true if the point is inside the grid
+func (g *Grid[T]) In(p Point) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < g.Width && p.Y < g.Height
+}
+
+// Get returns the cell at a point, false if the point is outside the grid
+func (g *Grid[T]) Get(p Point) (T, bool) {
+	if !g.In(p) {
+		var zero T
+		return zero, false
+	}
+	return g.Cells[p.Y*g.Width+p.X], true
+}
+
+// At returns the cell at a point, it panics if the point is outside the grid
+func (g *Grid[T]) At(p Point) T {
+	if !g.In(p) {
+		panic(fmt.Sprintf("grid: %s is outside the %dx%d grid", p, g.Width, g.Height))
+	}
+	return g.Cells[p.Y*g.Width+p.X]
+}
+
+// Set sets the cell at a point, false if the point is outside the grid
+func (g *Grid[T]) Set(p Point, v T) bool {
+	if !g.In(p) {
+		return false
+	}
+	g.Cells[p.Y*g.Width+p.X] = v
+	return true
+}
+
+// Row returns a copy of a row
+func (g *Grid[T]) Row(y int) []T {
+	row := make([]T, g.Width)
+	copy(row, g.Cells[y*g.Width:(y+1)*g.Width])
+	return row
+}
+
+// Col returns a copy of a column
+func (g *Grid[T]) Col(x int) []T {
+	col := make([]T, 0, g.Height)
+	for y := 0; y < g.Height; y++ {
+		col = append(col, g.Cells[y*g.Width+x])
+	}
+	return col
+}
+
+// Rows returns a copy of every row
+func (g *Grid[T]) Rows() [][]T {
+	rows := make([][]T, 0, g.Height)
+	for y := 0; y < g.Height; y++ {
+		rows = append(rows, g.Row(y))
+	}
+	return rows
+}
+
+// Clone returns a copy of the grid
+func (g *Grid[T]) Clone() *Grid[T] {
+	c := New[T](g.Width, g.Height)
+	copy(c.Cells, g.Cells)
+	return c
+}
+
+// Points iterates over every point of the grid, row by row
+func (g *Grid[T]) Points() iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for y := 0; y < g.Height; y++ {
+			for x := 0; x < g.Width; x++ {
+				if !yield(Point{X: x, Y: y}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// All iterates over every point and cell of the grid, row by row
+func (g *Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for p := range g.Points() {
+			if !yield(p, g.Cells[p.Y*g.Width+p.X]) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbours iterates over the points and cells next to a point in some directions, e.g. Directions(),
+// points outside the grid are skipped
+func (g *Grid[T]) Neighbours(p Point, dirs []Point) iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for _, d := range dirs {
+			n := p.Add(d)
+			v, ok := g.Get(n)
+			if !ok {
+				continue
+			}
+			if !yield(n, v) {
+				return
+			}
+		}
+	}
+}
+
+// Line iterates over the points and cells from a point in a direction, until it leaves the grid
+func (g *Grid[T]) Line(start Point, dir Point) iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		if dir == (Point{}) {
+			return
+		}
+		for p := start; g.In(p); p = p.Add(dir) {
+			if !yield(p, g.Cells[p.Y*g.Width+p.X]) {
+				return
+			}
+		}
+	}
+}
+
+// LineStarts returns the points the lines in a direction start from, i.e. the points whose previous point is
+// outside the grid, row by row
+func (g *Grid[T]) LineStarts(dir Point) []Point {
+	starts := make([]Point, 0)
+	if dir == (Point{}) {
+		return starts
+	}
+	for p := range g.Points() {
+		if !g.In(p.Sub(dir)) {
+			starts = append(starts, p)
+		}
+	}
+	return starts
+}
+
+// Lines iterates over every line through the grid in a direction, e.g. E gives the rows,
+// S the columns and SE the descending diagonals
+func (g *Grid[T]) Lines(dir Point) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for _, start := range g.LineStarts(dir) {
+			line := make([]T, 0)
+			for _, v := range g.Line(start, dir) {
+				line = append(line, v)
+			}
+			if !yield(line) {
+				return
+			}
+		}
+	}
+}
+
+// Map returns a new grid of the same size with f applied to every cell
+func Map[T any, U any](g *Grid[T], f func(T) U) *Grid[U] {
+	m := &Grid[U]{
+		Width:  g.Width,
+		Height: g.Height,
+		Cells:  make([]U, 0, len(g.Cells)),
+	}
+	for _, v := range g.Cells {
+		m.Cells = append(m.Cells, f(v))
+	}
+	return m
+}
@@ -0,0 +1,274 @@
+package grid_test
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common/grid"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/parse"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+// newGrid parses a grid of runes for a test
+func newGrid(t *testing.T, contents string) *grid.Grid[rune] {
+	t.Helper()
+	g, err := grid.Parse("test", []byte(contents))
+	assert.Nil(t, err)
+	return g
+}
+
+// lines returns every line of a grid in a direction as strings
+func lines(g *grid.Grid[rune], dir grid.Point) []string {
+	result := make([]string, 0)
+	for l := range g.Lines(dir) {
+		result = append(result, string(l))
+	}
+	return result
+}
+
+// TestParse is a test for the Parse and FromRows functions
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name        string
+		contents    string
+		expected    *grid.Grid[rune]
+		expectedErr error
+	}{
+		{
+			name:     "wide",
+			contents: "abc\ndef\n",
+			expected: &grid.Grid[rune]{Width: 3, Height: 2, Cells: []rune("abcdef")},
+		},
+		{
+			name:        "ragged",
+			contents:    "abc\nde\n",
+			expectedErr: parse.ErrParse{Resource: "test", Line: 2, Column: 3, Err: parse.ErrRagged{Expected: 3, Actual: 2}},
+		},
+		{
+			name:        "empty",
+			contents:    "\n",
+			expectedErr: parse.ErrParse{Resource: "test", Err: parse.ErrEmpty{}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			// Act
+			result, err := grid.Parse("test", []byte(tc.contents))
+			// Assert
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+	_, err := grid.FromRows([][]int{{1, 2}, {3}})
+	assert.Equal(t, grid.ErrRagged{Row: 1, Expected: 2, Actual: 1}, err)
+}
+
+// TestAccess is a test for the bounds checked access of Grid
+func TestAccess(t *testing.T) {
+	// Arrange
+	g := newGrid(t, "ab\ncd\nef")
+	// Act
+	v, ok := g.Get(grid.Point{X: 1, Y: 2})
+	_, outside := g.Get(grid.Point{X: 2, Y: 0})
+	set := g.Set(grid.Point{X: 0, Y: 0}, 'z')
+	setOutside := g.Set(grid.Point{X: -1, Y: 0}, 'z')
+	// Assert
+	assert.True(t, ok)
+	assert.Equal(t, 'f', v)
+	assert.False(t, outside)
+	assert.True(t, set)
+	assert.False(t, setOutside)
+	assert.Equal(t, 'z', g.At(grid.Point{X: 0, Y: 0}))
+	assert.Panics(t, func() { g.At(grid.Point{X: 0, Y: 3}) })
+	assert.Equal(t, []rune("bdf"), g.Col(1))
+	assert.Equal(t, []rune("cd"), g.Row(1))
+}
+
+// TestNeighbours is a test for the Neighbours method
+func TestNeighbours(t *testing.T) {
+	testCases := []struct {
+		name     string
+		point    grid.Point
+		dirs     []grid.Point
+		expected map[grid.Point]rune
+	}{
+		{
+			name:  "corner_all",
+			point: grid.Point{X: 0, Y: 0},
+			dirs:  grid.Directions(),
+			expected: map[grid.Point]rune{
+				{X: 1, Y: 0}: 'b',
+				{X: 1, Y: 1}: 'e',
+				{X: 0, Y: 1}: 'd',
+			},
+		},
+		{
+			name:  "middle_diagonal",
+			point: grid.Point{X: 1, Y: 1},
+			dirs:  grid.Diagonal(),
+			expected: map[grid.Point]rune{
+				{X: 2, Y: 0}: 'c',
+				{X: 2, Y: 2}: 'i',
+				{X: 0, Y: 2}: 'g',
+				{X: 0, Y: 0}: 'a',
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			g := newGrid(t, "abc\ndef\nghi")
+			// Act
+			result := maps.Collect(g.Neighbours(tc.point, tc.dirs))
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+// TestLines is a test for the Lines method
+func TestLines(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dir      grid.Point
+		expected []string
+	}{
+		{name: "rows", dir: grid.E, expected: []string{"abc", "def"}},
+		{name: "reverse_rows", dir: grid.W, expected: []string{"cba", "fed"}},
+		{name: "cols", dir: grid.S, expected: []string{"ad", "be", "cf"}},
+		{name: "reverse_cols", dir: grid.N, expected: []string{"da", "eb", "fc"}},
+		{name: "descending", dir: grid.SE, expected: []string{"ae", "bf", "c", "d"}},
+		{name: "ascending", dir: grid.NE, expected: []string{"a", "db", "ec", "f"}},
+		{name: "none", dir: grid.Point{}, expected: []string{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			g := newGrid(t, "abc\ndef")
+			// Act
+			result := lines(g, tc.dir)
+			// Assert
+			assert.ElementsMatch(t, tc.expected, result)
+		})
+	}
+}
+
+// TestTransforms is a test for the rotations, transpose and flips of Grid
+func TestTransforms(t *testing.T) {
+	testCases := []struct {
+		name      string
+		transform func(*grid.Grid[rune]) *grid.Grid[rune]
+		expected  string
+	}{
+		{name: "rotate90", transform: (*grid.Grid[rune]).Rotate90, expected: "da\neb\nfc"},
+		{name: "rotate2", transform: func(g *grid.Grid[rune]) *grid.Grid[rune] { return g.Rotate(2) }, expected: "fed\ncba"},
+		{name: "rotate_anticlockwise", transform: func(g *grid.Grid[rune]) *grid.Grid[rune] { return g.Rotate(-1) }, expected: "cf\nbe\nad"},
+		{name: "transpose", transform: (*grid.Grid[rune]).Transpose, expected: "ad\nbe\ncf"},
+		{name: "flip_h", transform: (*grid.Grid[rune]).FlipH, expected: "cba\nfed"},
+		{name: "flip_v", transform: (*grid.Grid[rune]).FlipV, expected: "def\nabc"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			g := newGrid(t, "abc\ndef")
+			// Act
+			result := tc.transform(g)
+			// Assert
+			assert.Equal(t, newGrid(t, tc.expected), result)
+			assert.Equal(t, newGrid(t, "abc\ndef"), g)
+		})
+	}
+}
+
+// TestWindows is a test for the Window and Windows methods
+func TestWindows(t *testing.T) {
+	// Arrange
+	g := newGrid(t, "abc\ndef\nghi")
+	// Act
+	origins := make([]grid.Point, 0)
+	windows := make([]*grid.Grid[rune], 0)
+	for p, w := range g.Windows(2, 2) {
+		origins = append(origins, p)
+		windows = append(windows, w)
+	}
+	_, outside := g.Window(grid.Point{X: 2, Y: 0}, 2, 1)
+	// Assert
+	assert.Equal(t, []grid.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}}, origins)
+	assert.Equal(t, newGrid(t, "ef\nhi"), windows[3])
+	assert.False(t, outside)
+	assert.Equal(t, 9, len(slices.Collect(g.Points())))
+}
+
+// TestName is a test for the Name method of Point and ParseDirection
+func TestName(t *testing.T) {
+	// Arrange
+	names := make([]string, 0)
+	// Act
+	for _, d := range grid.Directions() {
+		names = append(names, d.Name())
+	}
+	// Assert
+	assert.Equal(t, []string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}, names)
+	assert.Equal(t, "(2,-1)", grid.Point{X: 2, Y: -1}.Name())
+	for _, d := range grid.Directions() {
+		parsed, err := grid.ParseDirection(strings.ToLower(d.Name()))
+		assert.Nil(t, err)
+		assert.Equal(t, d, parsed)
+	}
+	_, err := grid.ParseDirection("up")
+	assert.Equal(t, grid.ErrUnknownDirection{Name: "up"}, err)
+}
+
+// TestMarshalPoint is a test for the json and yaml keys of a Point
+func TestMarshalPoint(t *testing.T) {
+	// Arrange
+	pt := grid.Point{X: 2, Y: -1}
+	// Act
+	j, jsonErr := json.Marshal(pt)
+	y, yamlErr := yaml.Marshal(pt)
+	// Assert
+	assert.Nil(t, jsonErr)
+	assert.Equal(t, `{"x":2,"y":-1}`, string(j))
+	assert.Nil(t, yamlErr)
+	keys := map[string]int{}
+	assert.Nil(t, yaml.Unmarshal(y, &keys))
+	assert.Equal(t, map[string]int{"x": 2, "y": -1}, keys)
+}
+
+// TestParseClusters is a test for the ParseClusters function
+func TestParseClusters(t *testing.T) {
+	testCases := []struct {
+		name        string
+		contents    string
+		expected    *grid.Grid[string]
+		expectedErr error
+	}{
+		{
+			name:     "decomposed",
+			contents: "e\u0301a\nb\U0001f1fa\U0001f1f8\n",
+			expected: &grid.Grid[string]{Width: 2, Height: 2, Cells: []string{"\u00e9", "a", "b", "\U0001f1fa\U0001f1f8"}},
+		},
+		{
+			name:        "ragged_by_cluster",
+			contents:    "e\u0301a\nab\u0301c\n",
+			expectedErr: parse.ErrParse{Resource: "test", Line: 2, Column: 3, Err: parse.ErrRagged{Expected: 2, Actual: 3}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			// Act
+			result, err := grid.ParseClusters("test", []byte(tc.contents), func(c string) (string, error) {
+				return c, nil
+			})
+			// Assert
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package grid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Point is a position in a grid, or a direction between positions, X is the column and Y the row from the top left
+type Point struct {
+	X int `json:"x" yaml:"x"`
+	Y int `json:"y" yaml:"y"`
+}
+
+var (
+	// N is the direction up a row
+	N = Point{X: 0, Y: -1}
+	// NE is the direction up a row and right a column
+	NE = Point{X: 1, Y: -1}
+	// E is the direction right a column
+	E = Point{X: 1, Y: 0}
+	// SE is the direction down a row and right a column
+	SE = Point{X: 1, Y: 1}
+	// S is the direction down a row
+	S = Point{X: 0, Y: 1}
+	// SW is the direction down a row and left a column
+	SW = Point{X: -1, Y: 1}
+	// W is the direction left a column
+	W = Point{X: -1, Y: 0}
+	// NW is the direction up a row and left a column
+	NW = Point{X: -1, Y: -1}
+)
+
+// Directions returns the 8 directions, clockwise from N
+func Directions() []Point {
+	return []Point{N, NE, E, SE, S, SW, W, NW}
+}
+
+// Orthogonal returns the 4 directions along rows and columns, clockwise from N
+func Orthogonal() []Point {
+	return []Point{N, E, S, W}
+}
+
+// Diagonal returns the 4 diagonal directions, clockwise from NE
+func Diagonal() []Point {
+	return []Point{NE, SE, SW, NW}
+}
+
+// directionNames are the names of the directions
+var directionNames = map[Point]string{
+	N:  "N",
+	NE: "NE",
+	E:  "E",
+	SE: "SE",
+	S:  "S",
+	SW: "SW",
+	W:  "W",
+	NW: "NW",
+}
+
+// Name returns the name of a direction, e.g. NE, or the point as (x,y) if it isn't one of the 8 directions
+func (p Point) Name() string {
+	if name, ok := directionNames[p]; ok {
+		return name
+	}
+	return p.String()
+}
+
+// ErrUnknownDirection is an error that is returned for a direction name that isn't one of the 8 directions
+type ErrUnknownDirection struct {
+	Name string
+}
+
+// Error returns the error message
+func (e ErrUnknownDirection) Error() string {
+	return fmt.Sprintf("unknown direction %q, expected one of N, NE, E, SE, S, SW, W or NW", e.Name)
+}
+
+// ParseDirection returns the direction with a name, e.g. NE, in any case
+func ParseDirection(name string) (Point, error) {
+	upper := strings.ToUpper(name)
+	for _, d := range Directions() {
+		if directionNames[d] == upper {
+			return d, nil
+		}
+	}
+	return Point{}, ErrUnknownDirection{Name: name}
+}
+
+// Add returns the point moved by another point
+func (p Point) Add(o Point) Point {
+	return Point{X: p.X + o.X, Y: p.Y + o.Y}
+}
+
+// Sub returns the point moved back by another point
+func (p Point) Sub(o Point) Point {
+	return Point{X: p.X - o.X, Y: p.Y - o.Y}
+}
+
+// Scale returns the point multiplied by n, e.g. a direction n steps long
+func (p Point) Scale(n int) Point {
+	return Point{X: p.X * n, Y: p.Y * n}
+}
+
+// Reverse returns the opposite direction
+func (p Point) Reverse() Point {
+	return p.Scale(-1)
+}
+
+// String returns the point as (x,y)
+func (p Point) String() string {
+	return fmt.Sprintf("(%d,%d)", p.X, p.Y)
+}
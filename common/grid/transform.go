@@ -0,0 +1,80 @@
+package grid
+
+import (
+	"iter"
+)
+
+// remap returns a new grid of a size where every point takes the cell of the point from returns
+func (g *Grid[T]) remap(width int, height int, from func(p Point) Point) *Grid[T] {
+	r := New[T](width, height)
+	for p := range r.Points() {
+		r.Cells[p.Y*width+p.X] = g.At(from(p))
+	}
+	return r
+}
+
+// Rotate90 returns the grid rotated 90 degrees clockwise
+func (g *Grid[T]) Rotate90() *Grid[T] {
+	return g.remap(g.Height, g.Width, func(p Point) Point {
+		return Point{X: p.Y, Y: g.Height - 1 - p.X}
+	})
+}
+
+// Rotate returns the grid rotated 90 degrees clockwise a number of times, negative is anticlockwise
+func (g *Grid[T]) Rotate(times int) *Grid[T] {
+	times = ((times % 4) + 4) % 4
+	r := g.Clone()
+	for i := 0; i < times; i++ {
+		r = r.Rotate90()
+	}
+	return r
+}
+
+// Transpose returns the grid flipped over its descending diagonal, rows become columns
+func (g *Grid[T]) Transpose() *Grid[T] {
+	return g.remap(g.Height, g.Width, func(p Point) Point {
+		return Point{X: p.Y, Y: p.X}
+	})
+}
+
+// FlipH returns the grid mirrored left to right
+func (g *Grid[T]) FlipH() *Grid[T] {
+	return g.remap(g.Width, g.Height, func(p Point) Point {
+		return Point{X: g.Width - 1 - p.X, Y: p.Y}
+	})
+}
+
+// FlipV returns the grid mirrored top to bottom
+func (g *Grid[T]) FlipV() *Grid[T] {
+	return g.remap(g.Width, g.Height, func(p Point) Point {
+		return Point{X: p.X, Y: g.Height - 1 - p.Y}
+	})
+}
+
+// Window returns a copy of the sub-grid with its top left at origin, false if it doesn't fit in the grid
+func (g *Grid[T]) Window(origin Point, width int, height int) (*Grid[T], bool) {
+	if width < 0 || height < 0 || !g.In(origin) || !g.In(origin.Add(Point{X: width - 1, Y: height - 1})) {
+		return nil, false
+	}
+	return g.remap(width, height, func(p Point) Point {
+		return origin.Add(p)
+	}), true
+}
+
+// Windows iterates over every sub-grid of a size that fits in the grid, by the point of its top left, row by row
+func (g *Grid[T]) Windows(width int, height int) iter.Seq2[Point, *Grid[T]] {
+	return func(yield func(Point, *Grid[T]) bool) {
+		for y := 0; y <= g.Height-height; y++ {
+			for x := 0; x <= g.Width-width; x++ {
+				origin := Point{X: x, Y: y}
+				w, ok := g.Window(origin, width, height)
+				if !ok {
+					return
+				}
+				if !yield(origin, w) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -1,10 +1,10 @@
 package common
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"log/slog"
-	"strconv"
-	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -15,6 +15,8 @@ type Helpers struct {
 	Viper     *viper.Viper
 	Logger    *slog.Logger
 	Resources *Resources
+	// logFile closes the log file of the logger, if it has one
+	logFile io.Closer
 }
 
 // ErrStreamsNil is an error that is returned when the streams is nil
@@ -69,17 +71,51 @@ func NewHelpers(s *Streams, v *viper.Viper, l *slog.Logger) (*Helpers, error) {
 	}, nil
 }
 
-// GetLines returns a slice of lines from a string
-func (h *Helpers) GetLines(s string) []string {
-	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+// WithStreams returns a copy of the helpers that uses other streams and logger, e.g. to discard output
+func (h *Helpers) WithStreams(s *Streams, l *slog.Logger) *Helpers {
+	c := *h
+	c.Streams = s
+	c.Logger = l
+	return &c
 }
 
-// ToInt converts a string to an int
-func (h *Helpers) ToInt(s string) int {
-	i, err := strconv.Atoi(s)
+// With returns a copy of the helpers whose logger adds attributes to every record, e.g. the day and star
+func (h *Helpers) With(args ...any) *Helpers {
+	c := *h
+	c.Logger = h.Logger.With(args...)
+	return &c
+}
+
+// DebugEnabled returns true if debug records are logged, check it before building expensive attributes
+func (h *Helpers) DebugEnabled() bool {
+	return h.Logger.Enabled(context.Background(), slog.LevelDebug)
+}
+
+// SetLogger replaces the logger and closes the log file of the one it replaces, c closes the new logger's log file
+func (h *Helpers) SetLogger(l *slog.Logger, c io.Closer) error {
+	err := h.Close()
+	h.Logger = l
+	h.logFile = c
+	slog.SetDefault(l)
+	return err
+}
+
+// Close closes the log file of the logger, call it once the command exits
+func (h *Helpers) Close() error {
+	if h.logFile == nil {
+		return nil
+	}
+	err := h.logFile.Close()
+	h.logFile = nil
+	return err
+}
+
+// LoadResources rebuilds the resources from the current viper configuration, e.g. once flags are parsed
+func (h *Helpers) LoadResources() error {
+	r, err := NewResources(h.Logger, h.Viper)
 	if err != nil {
-		h.Logger.Error(fmt.Sprintf("Error converting %s to int: %s", s, err))
-		panic(err)
+		return err
 	}
-	return i
+	h.Resources = r
+	return nil
 }
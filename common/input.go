@@ -0,0 +1,120 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+const (
+	// InputKey is the viper key for the path of the input, overriding the resources
+	InputKey = "input"
+	// StdinInput is the input path that reads the input from Streams.In
+	StdinInput = "-"
+	// stdinName is the file name given to input read from Streams.In
+	stdinName = "stdin"
+)
+
+// ErrResourceNotFound is an error that is returned when a resource doesn't exist
+type ErrResourceNotFound struct {
+	Name string
+}
+
+// Error returns the error message
+func (e ErrResourceNotFound) Error() string {
+	return fmt.Sprintf("resource not found: %s", e.Name)
+}
+
+// ErrEmptyInput is an error that is returned when an input has nothing but whitespace in it
+type ErrEmptyInput struct {
+	Name string
+}
+
+// Error returns the error message
+func (e ErrEmptyInput) Error() string {
+	return fmt.Sprintf("input %s is empty", e.Name)
+}
+
+// GetInput returns the input file for a solver, from the input path or example if set, otherwise the resources.
+// An empty input is an error, so a solver never answers 0 for an input it didn't get
+func GetInput(h *Helpers, s Solver) (*File, error) {
+	f, err := getInput(h, s)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(f.Contents)) == 0 {
+		err = ErrEmptyInput{Name: f.Name}
+		h.Logger.Error("Error getting input", ErrAttr, err)
+		return nil, err
+	}
+	return f, nil
+}
+
+// getInput returns the input file for a solver, see GetInput
+func getInput(h *Helpers, s Solver) (*File, error) {
+	path := h.Viper.GetString(InputKey)
+	example := h.Viper.GetInt(ExampleKey)
+	if path != "" && example > 0 {
+		return nil, ErrInputConflict{}
+	}
+	if example > 0 {
+		return GetExample(h, s, example)
+	}
+	switch path {
+	case "":
+		return getResource(h, s.Info().ResourceName())
+	case StdinInput:
+		return readInput(h, stdinName, nil, h.Streams.In)
+	default:
+		return readInputFile(h, path)
+	}
+}
+
+// getResource returns an input file from the resources
+func getResource(h *Helpers, name string) (*File, error) {
+	f := h.Resources.GetFile(h, name)
+	if f == nil {
+		err := ErrResourceNotFound{Name: name}
+		h.Logger.Error("Error getting input", ErrAttr, err)
+		return nil, err
+	}
+	return f, nil
+}
+
+// readInputFile reads the input file from a path on disk
+func readInputFile(h *Helpers, path string) (*File, error) {
+	h.Logger.Debug("Reading input file", "file", path)
+	f, err := os.Open(path)
+	if err != nil {
+		h.Logger.Error("Error opening input file", "file", path, ErrAttr, err)
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		h.Logger.Error("Error reading input file", "file", path, ErrAttr, err)
+		return nil, err
+	}
+	return readInput(h, path, fs.FileInfoToDirEntry(info), f)
+}
+
+// readInput reads the input from a reader
+func readInput(h *Helpers, name string, de fs.DirEntry, r io.Reader) (*File, error) {
+	if r == nil {
+		err := ErrStreamsNil{}
+		h.Logger.Error("Error reading input", "file", name, ErrAttr, err)
+		return nil, err
+	}
+	contents, err := io.ReadAll(r)
+	if err != nil {
+		h.Logger.Error("Error reading input", "file", name, ErrAttr, err)
+		return nil, err
+	}
+	return &File{
+		Name:     name,
+		DirEntry: de,
+		Contents: contents,
+	}, nil
+}
@@ -0,0 +1,115 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+)
+
+const (
+	// LedgerKey is the viper key for the path of the known answer ledger
+	LedgerKey = "ledger"
+	// DefaultLedger is the default path of the known answer ledger
+	DefaultLedger = "answers.json"
+	// LedgerVersion is the version of the ledger file format
+	LedgerVersion = 1
+)
+
+// Ledger is a struct that contains the accepted answers, keyed by day, star and input hash
+type Ledger struct {
+	Path    string         `json:"-"`
+	Version int            `json:"version"`
+	Answers []*LedgerEntry `json:"answers"`
+}
+
+// LedgerEntry is a struct that contains an accepted answer
+type LedgerEntry struct {
+	Day       int    `json:"day"`
+	Star      Star   `json:"star"`
+	InputHash string `json:"inputHash"`
+	Answer    int    `json:"answer"`
+}
+
+// ErrLedgerVersion is an error that is returned when the ledger file has an unsupported version
+type ErrLedgerVersion struct {
+	Path    string
+	Version int
+}
+
+// Error returns the error message
+func (e ErrLedgerVersion) Error() string {
+	return fmt.Sprintf("ledger %s has version %d, expected %d", e.Path, e.Version, LedgerVersion)
+}
+
+// LoadLedger loads the ledger from a path, empty if the file doesn't exist yet
+func LoadLedger(path string) (*Ledger, error) {
+	l := &Ledger{
+		Path:    path,
+		Version: LedgerVersion,
+	}
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(contents, l)
+	if err != nil {
+		return nil, err
+	}
+	if l.Version != LedgerVersion {
+		return nil, ErrLedgerVersion{Path: path, Version: l.Version}
+	}
+	return l, nil
+}
+
+// LedgerPath returns the path of the ledger from viper, or the default
+func LedgerPath(h *Helpers) string {
+	path := h.Viper.GetString(LedgerKey)
+	if path == "" {
+		return DefaultLedger
+	}
+	return path
+}
+
+// Lookup returns the accepted answer for a day, star and input hash, nil if there isn't one
+func (l *Ledger) Lookup(day int, star Star, inputHash string) *LedgerEntry {
+	for _, e := range l.Answers {
+		if e.Day == day && e.Star == star && e.InputHash == inputHash {
+			return e
+		}
+	}
+	return nil
+}
+
+// Record records an accepted answer, replacing any answer for the same day, star and input hash
+func (l *Ledger) Record(entry *LedgerEntry) {
+	if e := l.Lookup(entry.Day, entry.Star, entry.InputHash); e != nil {
+		e.Answer = entry.Answer
+		return
+	}
+	l.Answers = append(l.Answers, entry)
+}
+
+// Save writes the ledger back to disk, ordered so diffs stay small
+func (l *Ledger) Save() error {
+	sort.SliceStable(l.Answers, func(i, j int) bool {
+		a, b := l.Answers[i], l.Answers[j]
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		if a.Star != b.Star {
+			return a.Star < b.Star
+		}
+		return a.InputHash < b.InputHash
+	})
+	contents, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(l.Path, append(contents, '\n'), 0o644)
+}
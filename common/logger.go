@@ -0,0 +1,122 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// LogLevelKey is the viper key for the log level
+	LogLevelKey = "log-level"
+	// LogFormatKey is the viper key for the log format
+	LogFormatKey = "log-format"
+	// JSONLogFormat logs json lines
+	JSONLogFormat = "json"
+	// TextLogFormat logs key=value lines
+	TextLogFormat = "text"
+	// PrettyLogFormat logs coloured lines grouped by day and star, for reading in a terminal
+	PrettyLogFormat = "pretty"
+	// LogFileKey is the viper key for the file logs are appended to instead of the error stream
+	LogFileKey = "log-file"
+
+	// DayAttr is the log attribute for the day being solved
+	DayAttr = "day"
+	// StarAttr is the log attribute for the star being solved
+	StarAttr = "star"
+	// PhaseAttr is the log attribute for what a solver is doing, see ParsePhase and SolvePhase
+	PhaseAttr = "phase"
+	// ErrAttr is the log attribute for an error
+	ErrAttr = "err"
+	// ParsePhase is the phase where the input is parsed
+	ParsePhase = "parse"
+	// SolvePhase is the phase where a star is solved
+	SolvePhase = "solve"
+)
+
+// LogFormats returns every log format
+func LogFormats() []string {
+	return []string{JSONLogFormat, TextLogFormat, PrettyLogFormat}
+}
+
+// ErrUnknownLogLevel is an error that is returned for a log level that doesn't exist
+type ErrUnknownLogLevel struct {
+	Level string
+}
+
+// Error returns the error message
+func (e ErrUnknownLogLevel) Error() string {
+	return fmt.Sprintf("unknown log level %q, expected one of debug, info, warn, error", e.Level)
+}
+
+// ErrUnknownLogFormat is an error that is returned for a log format that doesn't exist
+type ErrUnknownLogFormat struct {
+	Format string
+}
+
+// Error returns the error message
+func (e ErrUnknownLogFormat) Error() string {
+	return fmt.Sprintf("unknown log format %q, expected one of %v", e.Format, LogFormats())
+}
+
+// ParseLogLevel parses a log level, empty is info
+func ParseLogLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, ErrUnknownLogLevel{Level: level}
+	}
+}
+
+// nopCloser is the closer of a logger without a log file, there is nothing to close
+type nopCloser struct{}
+
+// Close does nothing
+func (nopCloser) Close() error {
+	return nil
+}
+
+// NewLogger creates a new logger writing to w, or the log file if there is one, configured from viper.
+// The closer closes the log file, see Helpers.SetLogger
+func NewLogger(w io.Writer, v *viper.Viper) (*slog.Logger, io.Closer, error) {
+	level, err := ParseLogLevel(v.GetString(LogLevelKey))
+	if err != nil {
+		return nil, nil, err
+	}
+	format := strings.ToLower(v.GetString(LogFormatKey))
+	if format != "" && !slices.Contains(LogFormats(), format) {
+		return nil, nil, ErrUnknownLogFormat{Format: format}
+	}
+	var c io.Closer = nopCloser{}
+	if path := v.GetString(LogFileKey); path != "" {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, nil, err
+		}
+		w, c = f, f
+	}
+	opts := &slog.HandlerOptions{
+		AddSource: level == slog.LevelDebug,
+		Level:     level,
+	}
+	switch format {
+	case TextLogFormat:
+		return slog.New(slog.NewTextHandler(w, opts)), c, nil
+	case PrettyLogFormat:
+		return slog.New(NewPrettyHandler(w, opts, IsTerminal(w))), c, nil
+	default:
+		return slog.New(slog.NewJSONHandler(w, opts)), c, nil
+	}
+}
@@ -0,0 +1,41 @@
+package common_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSetLogger is a test for the SetLogger and Close functions closing the log files
+func TestSetLogger(t *testing.T) {
+	// Arrange
+	s := test.NewTestStreams()
+	v := test.NewTestViper(t)
+	v.Set(common.LogFileKey, filepath.Join(t.TempDir(), "aoc.log"))
+	first, firstFile, err := common.NewLogger(s.ErrOut, v)
+	assert.Nil(t, err)
+	h, err := common.NewHelpers(s.Streams, v, first)
+	assert.Nil(t, err)
+	assert.Nil(t, h.SetLogger(first, firstFile))
+	second, secondFile, err := common.NewLogger(s.ErrOut, v)
+	assert.Nil(t, err)
+	// Act
+	err = h.SetLogger(second, secondFile)
+	// Assert
+	assert.Nil(t, err)
+	_, err = firstFile.(*os.File).WriteString("closed\n")
+	assert.ErrorIs(t, err, os.ErrClosed)
+	_, err = secondFile.(*os.File).WriteString("open\n")
+	assert.Nil(t, err)
+	// Act
+	err = h.Close()
+	// Assert
+	assert.Nil(t, err)
+	_, err = secondFile.(*os.File).WriteString("closed\n")
+	assert.ErrorIs(t, err, os.ErrClosed)
+	assert.Nil(t, h.Close())
+}
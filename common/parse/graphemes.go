@@ -0,0 +1,71 @@
+package parse
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// zwj is the zero width joiner, it joins the characters either side of it into one, e.g. in emoji sequences
+	zwj = '\u200d'
+)
+
+// Graphemes returns the user-perceived characters of text in NFC, so an accented letter is one character however
+// it was typed. The clusters are an approximation of the extended grapheme clusters of UAX #29: a base followed by
+// its combining marks, emoji modifiers, and any characters joined to it with a zero width joiner, and pairs of
+// regional indicators (flags)
+func Graphemes(text string) []string {
+	if isASCII(text) {
+		clusters := make([]string, 0, len(text))
+		for i := 0; i < len(text); i++ {
+			clusters = append(clusters, text[i:i+1])
+		}
+		return clusters
+	}
+	text = norm.NFC.String(text)
+	clusters := make([]string, 0, len(text))
+	start := 0
+	// joined is true if the last rune was a zero width joiner, so the next rune extends the cluster whatever it is
+	joined := false
+	// flag is true if the cluster is a single regional indicator, so another one makes a flag
+	flag := false
+	for i, r := range text {
+		switch {
+		case i == start:
+			flag = isRegionalIndicator(r)
+		case joined || extends(r) || (flag && isRegionalIndicator(r)):
+			flag = false
+		default:
+			clusters = append(clusters, text[start:i])
+			start = i
+			flag = isRegionalIndicator(r)
+		}
+		joined = r == zwj
+	}
+	if start < len(text) {
+		clusters = append(clusters, text[start:])
+	}
+	return clusters
+}
+
+// extends checks if a rune belongs to the cluster before it rather than starting a new one
+func extends(r rune) bool {
+	return unicode.Is(unicode.M, r) || r == zwj || (r >= 0x1f3fb && r <= 0x1f3ff)
+}
+
+// isRegionalIndicator checks if a rune is one of the letters two of which make a flag
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// isASCII checks if text is only ascii, every byte of it is then a cluster of its own
+func isASCII(text string) bool {
+	for i := 0; i < len(text); i++ {
+		if text[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
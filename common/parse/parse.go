@@ -0,0 +1,225 @@
+package parse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// intMatch matches an integer, with an optional sign
+var intMatch = regexp.MustCompile(`[-+]?\d+`)
+
+// ErrParse is an error at a position in a resource, Line and Column start at 1 and are 0 when not known
+type ErrParse struct {
+	Resource string
+	Line     int
+	Column   int
+	Err      error
+}
+
+// Error returns the error message, e.g. day1-star1:3:5: "x" isn't an integer
+func (e ErrParse) Error() string {
+	pos := e.Resource
+	if e.Line > 0 {
+		pos = fmt.Sprintf("%s:%d", pos, e.Line)
+		if e.Column > 0 {
+			pos = fmt.Sprintf("%s:%d", pos, e.Column)
+		}
+	}
+	return fmt.Sprintf("%s: %s", pos, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e ErrParse) Unwrap() error {
+	return e.Err
+}
+
+// ErrNotInt is an error that is returned when text isn't an integer
+type ErrNotInt struct {
+	Text string
+}
+
+// Error returns the error message
+func (e ErrNotInt) Error() string {
+	return fmt.Sprintf("%q isn't an integer", e.Text)
+}
+
+// ErrFieldCount is an error that is returned when a line has the wrong number of fields
+type ErrFieldCount struct {
+	Expected int
+	Actual   int
+}
+
+// Error returns the error message
+func (e ErrFieldCount) Error() string {
+	return fmt.Sprintf("expected %d fields, got %d", e.Expected, e.Actual)
+}
+
+// ErrRagged is an error that is returned when a grid row is a different width to the first row
+type ErrRagged struct {
+	Expected int
+	Actual   int
+}
+
+// Error returns the error message
+func (e ErrRagged) Error() string {
+	return fmt.Sprintf("expected a row of %d cells, got %d", e.Expected, e.Actual)
+}
+
+// ErrEmpty is an error that is returned when there is nothing to parse
+type ErrEmpty struct{}
+
+// Error returns the error message
+func (e ErrEmpty) Error() string {
+	return "no input"
+}
+
+// Line is a line of a resource, Number starts at 1
+type Line struct {
+	Resource string
+	Number   int
+	Text     string
+}
+
+// Field is a run of non-space text in a line, Column starts at 1
+type Field struct {
+	Text   string
+	Column int
+}
+
+// Lines returns the lines of a resource, blank lines are skipped but still counted
+func Lines(resource string, contents []byte) []Line {
+	lines := make([]Line, 0)
+	for i, text := range split(contents) {
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		lines = append(lines, Line{Resource: resource, Number: i + 1, Text: text})
+	}
+	return lines
+}
+
+// Sections returns the lines of a resource grouped by the empty lines between them, a line of spaces isn't empty
+func Sections(resource string, contents []byte) [][]Line {
+	sections := make([][]Line, 0)
+	var section []Line
+	for i, text := range split(contents) {
+		if text == "" {
+			if len(section) > 0 {
+				sections = append(sections, section)
+				section = nil
+			}
+			continue
+		}
+		section = append(section, Line{Resource: resource, Number: i + 1, Text: text})
+	}
+	if len(section) > 0 {
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// Int parses an integer, with an optional sign
+func Int(text string) (int, error) {
+	i, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, ErrNotInt{Text: text}
+	}
+	return i, nil
+}
+
+// At returns an error at a byte offset of a resource
+func At(resource string, contents []byte, offset int, err error) ErrParse {
+	before := string(contents[:min(max(offset, 0), len(contents))])
+	line := strings.Count(before, "\n") + 1
+	start := strings.LastIndex(before, "\n") + 1
+	return ErrParse{
+		Resource: resource,
+		Line:     line,
+		Column:   utf8.RuneCountInString(before[start:]) + 1,
+		Err:      err,
+	}
+}
+
+// Err returns an error at a column of the line, 0 if the column isn't known
+func (l Line) Err(column int, err error) ErrParse {
+	return ErrParse{
+		Resource: l.Resource,
+		Line:     l.Number,
+		Column:   column,
+		Err:      err,
+	}
+}
+
+// Fields returns the whitespace separated fields of the line
+func (l Line) Fields() []Field {
+	fields := make([]Field, 0)
+	start := -1
+	column := 0
+	startColumn := 0
+	for i, r := range l.Text {
+		column++
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				fields = append(fields, Field{Text: l.Text[start:i], Column: startColumn})
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			startColumn = column
+		}
+	}
+	if start >= 0 {
+		fields = append(fields, Field{Text: l.Text[start:], Column: startColumn})
+	}
+	return fields
+}
+
+// FieldInts returns the whitespace separated fields of the line as integers, every field must be one
+func (l Line) FieldInts() ([]int, error) {
+	fields := l.Fields()
+	ints := make([]int, 0, len(fields))
+	for _, f := range fields {
+		i, err := Int(f.Text)
+		if err != nil {
+			return nil, l.Err(f.Column, err)
+		}
+		ints = append(ints, i)
+	}
+	return ints, nil
+}
+
+// ExactInts returns the whitespace separated fields of the line as integers, there must be exactly n of them
+func (l Line) ExactInts(n int) ([]int, error) {
+	ints, err := l.FieldInts()
+	if err != nil {
+		return nil, err
+	}
+	if len(ints) != n {
+		return nil, l.Err(0, ErrFieldCount{Expected: n, Actual: len(ints)})
+	}
+	return ints, nil
+}
+
+// Ints returns every integer in the line, ignoring the text around them, e.g. "x=-1, y=2" is -1 and 2
+func (l Line) Ints() ([]int, error) {
+	ints := make([]int, 0)
+	for _, loc := range intMatch.FindAllStringIndex(l.Text, -1) {
+		i, err := Int(l.Text[loc[0]:loc[1]])
+		if err != nil {
+			return nil, l.Err(utf8.RuneCountInString(l.Text[:loc[0]])+1, err)
+		}
+		ints = append(ints, i)
+	}
+	return ints, nil
+}
+
+// split splits contents into lines, without the carriage returns of windows line endings
+func split(contents []byte) []string {
+	return strings.Split(strings.ReplaceAll(string(contents), "\r\n", "\n"), "\n")
+}
@@ -0,0 +1,167 @@
+package parse_test
+
+import (
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLines is a test for the Lines function
+func TestLines(t *testing.T) {
+	testCases := []struct {
+		name     string
+		contents string
+		expected []parse.Line
+	}{
+		{
+			name:     "trailing_newline",
+			contents: "a\nb\n",
+			expected: []parse.Line{
+				{Resource: "r", Number: 1, Text: "a"},
+				{Resource: "r", Number: 2, Text: "b"},
+			},
+		},
+		{
+			name:     "blank_lines_counted",
+			contents: "a\r\n\r\n  \nb",
+			expected: []parse.Line{
+				{Resource: "r", Number: 1, Text: "a"},
+				{Resource: "r", Number: 4, Text: "b"},
+			},
+		},
+		{
+			name:     "empty",
+			contents: "",
+			expected: []parse.Line{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			// Act
+			result := parse.Lines("r", []byte(tc.contents))
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+// TestSections is a test for the Sections function
+func TestSections(t *testing.T) {
+	// Arrange
+	contents := "\na\nb\n\n\nc\n  \nd\n"
+	// Act
+	result := parse.Sections("r", []byte(contents))
+	// Assert
+	assert.Equal(t, [][]parse.Line{
+		{{Resource: "r", Number: 2, Text: "a"}, {Resource: "r", Number: 3, Text: "b"}},
+		{{Resource: "r", Number: 6, Text: "c"}, {Resource: "r", Number: 7, Text: "  "}, {Resource: "r", Number: 8, Text: "d"}},
+	}, result)
+}
+
+// TestFields is a test for the Fields method
+func TestFields(t *testing.T) {
+	// Arrange
+	l := parse.Line{Resource: "r", Number: 1, Text: "  ab\tcé  d"}
+	// Act
+	result := l.Fields()
+	// Assert
+	assert.Equal(t, []parse.Field{
+		{Text: "ab", Column: 3},
+		{Text: "cé", Column: 6},
+		{Text: "d", Column: 10},
+	}, result)
+}
+
+// TestInts is a test for the integer methods of Line
+func TestInts(t *testing.T) {
+	testCases := []struct {
+		name        string
+		text        string
+		ints        func(parse.Line) ([]int, error)
+		expected    []int
+		expectedErr error
+	}{
+		{
+			name:     "field_ints",
+			text:     "3   -4",
+			ints:     parse.Line.FieldInts,
+			expected: []int{3, -4},
+		},
+		{
+			name:        "field_ints_not_int",
+			text:        "3   x4",
+			ints:        parse.Line.FieldInts,
+			expectedErr: parse.ErrParse{Resource: "r", Line: 7, Column: 5, Err: parse.ErrNotInt{Text: "x4"}},
+		},
+		{
+			name:        "exact_ints_too_few",
+			text:        "3",
+			ints:        func(l parse.Line) ([]int, error) { return l.ExactInts(2) },
+			expectedErr: parse.ErrParse{Resource: "r", Line: 7, Err: parse.ErrFieldCount{Expected: 2, Actual: 1}},
+		},
+		{
+			name:     "all_ints",
+			text:     "p=0,-4 v=+3,10",
+			ints:     parse.Line.Ints,
+			expected: []int{0, -4, 3, 10},
+		},
+		{
+			name:        "all_ints_overflow",
+			text:        "a 99999999999999999999",
+			ints:        parse.Line.Ints,
+			expectedErr: parse.ErrParse{Resource: "r", Line: 7, Column: 3, Err: parse.ErrNotInt{Text: "99999999999999999999"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			l := parse.Line{Resource: "r", Number: 7, Text: tc.text}
+			// Act
+			result, err := tc.ints(l)
+			// Assert
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+// TestAt is a test for the At function and the message of ErrParse
+func TestAt(t *testing.T) {
+	// Arrange
+	contents := []byte("mul(1,2)\nxmul(1000,2)")
+	// Act
+	err := parse.At("day3-star1", contents, 14, parse.ErrNotInt{Text: "1000"})
+	// Assert
+	assert.Equal(t, parse.ErrParse{Resource: "day3-star1", Line: 2, Column: 6, Err: parse.ErrNotInt{Text: "1000"}}, err)
+	assert.Equal(t, `day3-star1:2:6: "1000" isn't an integer`, err.Error())
+}
+
+// TestGraphemes is a test for the Graphemes function
+func TestGraphemes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{name: "ascii", text: "XMAS", expected: []string{"X", "M", "A", "S"}},
+		{name: "greek", text: "ΣΟΦ", expected: []string{"Σ", "Ο", "Φ"}},
+		{name: "decomposed_is_composed", text: "éa", expected: []string{"é", "a"}},
+		{name: "mark_without_composition", text: "q\u0303x", expected: []string{"q\u0303", "x"}},
+		{name: "hangul_jamo", text: "한", expected: []string{"한"}},
+		{name: "flags", text: "\U0001f1fa\U0001f1f8\U0001f1eb\U0001f1f7", expected: []string{"\U0001f1fa\U0001f1f8", "\U0001f1eb\U0001f1f7"}},
+		{name: "zwj_sequence", text: "a\U0001f468\u200d\U0001f469\u200d\U0001f467b", expected: []string{"a", "\U0001f468\u200d\U0001f469\u200d\U0001f467", "b"}},
+		{name: "skin_tone", text: "\U0001f44d\U0001f3fdx", expected: []string{"\U0001f44d\U0001f3fd", "x"}},
+		{name: "empty", text: "", expected: []string{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			// Act
+			result := parse.Graphemes(tc.text)
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ansiReset resets the colour
+	ansiReset = "\x1b[0m"
+	// ansiBold makes the text bold
+	ansiBold = "\x1b[1m"
+	// ansiFaint makes the text faint
+	ansiFaint = "\x1b[2m"
+	// ansiRed makes the text red
+	ansiRed = "\x1b[31m"
+	// ansiGreen makes the text green
+	ansiGreen = "\x1b[32m"
+	// ansiYellow makes the text yellow
+	ansiYellow = "\x1b[33m"
+	// ansiBlue makes the text blue
+	ansiBlue = "\x1b[34m"
+	// prettyTimeFormat is the format of the time on each line
+	prettyTimeFormat = "15:04:05.000"
+)
+
+// prettyState is the state shared by a pretty handler and the handlers derived from it
+type prettyState struct {
+	mu    sync.Mutex
+	w     io.Writer
+	group string
+}
+
+// PrettyHandler is a slog handler for people reading a terminal, lines are grouped under a heading for their day and star
+type PrettyHandler struct {
+	opts   slog.HandlerOptions
+	color  bool
+	state  *prettyState
+	attrs  []slog.Attr
+	groups []string
+	day    string
+	star   string
+}
+
+// NewPrettyHandler creates a new pretty handler writing to w, coloured if color is true
+func NewPrettyHandler(w io.Writer, opts *slog.HandlerOptions, color bool) *PrettyHandler {
+	p := &PrettyHandler{
+		color: color,
+		state: &prettyState{w: w},
+	}
+	if opts != nil {
+		p.opts = *opts
+	}
+	return p
+}
+
+// IsTerminal returns true if w is a terminal, colour is only used for terminals and never if NO_COLOR is set
+func IsTerminal(w io.Writer) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Enabled returns true if records at a level are written
+func (p *PrettyHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if p.opts.Level != nil {
+		minLevel = p.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+// WithAttrs returns a handler that adds attributes to every record
+func (p *PrettyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *p
+	c.attrs = slices.Clip(p.attrs)
+	for _, a := range attrs {
+		if c.heading(a) {
+			continue
+		}
+		c.attrs = append(c.attrs, p.qualify(a))
+	}
+	return &c
+}
+
+// WithGroup returns a handler that puts the attributes of every record in a group
+func (p *PrettyHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return p
+	}
+	c := *p
+	c.groups = append(slices.Clip(p.groups), name)
+	return &c
+}
+
+// heading keeps a day or star attribute for the heading and returns true, it checks the key before qualify so the
+// heading is found inside groups too
+func (p *PrettyHandler) heading(a slog.Attr) bool {
+	switch a.Key {
+	case DayAttr:
+		p.day = a.Value.Resolve().String()
+	case StarAttr:
+		p.star = a.Value.Resolve().String()
+	default:
+		return false
+	}
+	return true
+}
+
+// qualify prefixes the key of an attribute with the open groups
+func (p *PrettyHandler) qualify(a slog.Attr) slog.Attr {
+	if len(p.groups) == 0 {
+		return a
+	}
+	a.Key = strings.Join(append(slices.Clone(p.groups), a.Key), ".")
+	return a
+}
+
+// Handle writes a record, with a heading first if the day or star changed
+func (p *PrettyHandler) Handle(_ context.Context, r slog.Record) error {
+	c := *p
+	attrs := slices.Clone(p.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		if !c.heading(a) {
+			attrs = append(attrs, p.qualify(a))
+		}
+		return true
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString("  ")
+	if !r.Time.IsZero() {
+		buf.WriteString(p.paint(ansiFaint, r.Time.Format(prettyTimeFormat)))
+		buf.WriteString(" ")
+	}
+	buf.WriteString(p.level(r.Level))
+	buf.WriteString(" ")
+	buf.WriteString(r.Message)
+	for _, a := range attrs {
+		a.Value = a.Value.Resolve()
+		if a.Equal(slog.Attr{}) {
+			continue
+		}
+		p.writeAttr(&buf, "", a)
+	}
+	if p.opts.AddSource && r.PC != 0 {
+		frames := runtime.CallersFrames([]uintptr{r.PC})
+		frame, _ := frames.Next()
+		buf.WriteString(" ")
+		buf.WriteString(p.paint(ansiFaint, fmt.Sprintf("(%s:%d)", shortSource(frame.File), frame.Line)))
+	}
+	buf.WriteString("\n")
+
+	p.state.mu.Lock()
+	defer p.state.mu.Unlock()
+	group := prettyGroup(c.day, c.star)
+	if group != p.state.group {
+		p.state.group = group
+		_, err := io.WriteString(p.state.w, p.paint(ansiBold, fmt.Sprintf("── %s ──", group))+"\n")
+		if err != nil {
+			return err
+		}
+	}
+	_, err := p.state.w.Write(buf.Bytes())
+	return err
+}
+
+// writeAttr writes an attribute as key=value, groups are flattened into dotted keys
+func (p *PrettyHandler) writeAttr(buf *bytes.Buffer, prefix string, a slog.Attr) {
+	key := a.Key
+	if prefix != "" {
+		key = prefix + "." + key
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		for _, ga := range a.Value.Group() {
+			ga.Value = ga.Value.Resolve()
+			p.writeAttr(buf, key, ga)
+		}
+		return
+	}
+	value := a.Value.String()
+	switch a.Value.Kind() {
+	case slog.KindTime:
+		value = a.Value.Time().Format(time.RFC3339)
+	case slog.KindString:
+		if strings.ContainsAny(value, " \t\n\"=") || value == "" {
+			value = fmt.Sprintf("%q", value)
+		}
+	}
+	buf.WriteString(" ")
+	buf.WriteString(p.paint(ansiFaint, key+"="))
+	if key == ErrAttr {
+		value = p.paint(ansiRed, value)
+	}
+	buf.WriteString(value)
+}
+
+// level returns the fixed width, coloured name of a level
+func (p *PrettyHandler) level(l slog.Level) string {
+	switch {
+	case l >= slog.LevelError:
+		return p.paint(ansiRed, "ERR")
+	case l >= slog.LevelWarn:
+		return p.paint(ansiYellow, "WRN")
+	case l >= slog.LevelInfo:
+		return p.paint(ansiGreen, "INF")
+	default:
+		return p.paint(ansiBlue, "DBG")
+	}
+}
+
+// paint wraps text in a colour, if colour is on
+func (p *PrettyHandler) paint(color string, text string) string {
+	if !p.color {
+		return text
+	}
+	return color + text + ansiReset
+}
+
+// prettyGroup returns the heading for a day and star, e.g. Day 1 Star 2
+func prettyGroup(day string, star string) string {
+	switch {
+	case day == "":
+		return "General"
+	case star == "":
+		return fmt.Sprintf("Day %s", day)
+	default:
+		return fmt.Sprintf("Day %s Star %s", day, star)
+	}
+}
+
+// shortSource returns the last directory and the file name of a source path, e.g. day1/lists.go
+func shortSource(file string) string {
+	return filepath.Join(filepath.Base(filepath.Dir(file)), filepath.Base(file))
+}
@@ -0,0 +1,97 @@
+package common_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPrettyHandler is a test for the PrettyHandler struct
+func TestPrettyHandler(t *testing.T) {
+	testCases := []struct {
+		name     string
+		color    bool
+		expected string
+	}{
+		{
+			name:  "plain",
+			color: false,
+			expected: "── General ──\n" +
+				"  INF Solving days=2\n" +
+				"── Day 1 ──\n" +
+				"  DBG Parsed phase=parse lines=3\n" +
+				"── Day 1 Star 2 ──\n" +
+				"  ERR Error solving phase=solve err=\"bad input\"\n" +
+				"── Day 2 ──\n" +
+				"  WRN Slow puzzle.size=3\n" +
+				"── Day 3 ──\n" +
+				"  INF Grouped run.lines=1\n" +
+				"── Day 3 Star 1 ──\n" +
+				"  INF Starred\n",
+		},
+		{
+			name:  "color",
+			color: true,
+			expected: "\x1b[1m── General ──\x1b[0m\n" +
+				"  \x1b[32mINF\x1b[0m Solving \x1b[2mdays=\x1b[0m2\n" +
+				"\x1b[1m── Day 1 ──\x1b[0m\n" +
+				"  \x1b[34mDBG\x1b[0m Parsed \x1b[2mphase=\x1b[0mparse \x1b[2mlines=\x1b[0m3\n" +
+				"\x1b[1m── Day 1 Star 2 ──\x1b[0m\n" +
+				"  \x1b[31mERR\x1b[0m Error solving \x1b[2mphase=\x1b[0msolve \x1b[2merr=\x1b[0m\x1b[31m\"bad input\"\x1b[0m\n" +
+				"\x1b[1m── Day 2 ──\x1b[0m\n" +
+				"  \x1b[33mWRN\x1b[0m Slow \x1b[2mpuzzle.size=\x1b[0m3\n" +
+				"\x1b[1m── Day 3 ──\x1b[0m\n" +
+				"  \x1b[32mINF\x1b[0m Grouped \x1b[2mrun.lines=\x1b[0m1\n" +
+				"\x1b[1m── Day 3 Star 1 ──\x1b[0m\n" +
+				"  \x1b[32mINF\x1b[0m Starred\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var buf bytes.Buffer
+			h := common.NewPrettyHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}, tc.color)
+			day1 := h.WithAttrs([]slog.Attr{slog.Int(common.DayAttr, 1)})
+			star2 := day1.WithAttrs([]slog.Attr{slog.Int(common.StarAttr, 2), slog.String(common.PhaseAttr, common.SolvePhase)})
+			day2 := h.WithAttrs([]slog.Attr{slog.Int(common.DayAttr, 2)}).WithGroup("puzzle")
+			day3 := h.WithGroup("run").WithAttrs([]slog.Attr{slog.Int(common.DayAttr, 3)})
+			records := []struct {
+				handler slog.Handler
+				level   slog.Level
+				msg     string
+				attrs   []slog.Attr
+			}{
+				{h, slog.LevelInfo, "Solving", []slog.Attr{slog.Int("days", 2)}},
+				{day1, slog.LevelDebug, "Parsed", []slog.Attr{slog.String(common.PhaseAttr, common.ParsePhase), slog.Int("lines", 3)}},
+				{star2, slog.LevelError, "Error solving", []slog.Attr{slog.String(common.ErrAttr, "bad input")}},
+				{day2, slog.LevelWarn, "Slow", []slog.Attr{slog.Int("size", 3)}},
+				{day3, slog.LevelInfo, "Grouped", []slog.Attr{slog.Int("lines", 1)}},
+				{day3, slog.LevelInfo, "Starred", []slog.Attr{slog.Int(common.StarAttr, 1)}},
+			}
+			// Act
+			for _, r := range records {
+				record := slog.NewRecord(time.Time{}, r.level, r.msg, 0)
+				record.AddAttrs(r.attrs...)
+				assert.Nil(t, r.handler.Handle(context.Background(), record))
+			}
+			// Assert
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+// TestPrettyHandlerSource is a test for the shortened source paths of the PrettyHandler struct
+func TestPrettyHandlerSource(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	l := slog.New(common.NewPrettyHandler(&buf, &slog.HandlerOptions{AddSource: true}, false))
+	// Act
+	l.Info("Hello")
+	// Assert
+	assert.Regexp(t, `^── General ──\n  \d\d:\d\d:\d\d\.\d{3} INF Hello \(common/pretty_test\.go:\d+\)\n$`, buf.String())
+}
@@ -0,0 +1,71 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Registry is a struct that contains the solvers by day
+type Registry struct {
+	solvers map[int]Solver
+}
+
+// ErrDuplicateSolver is an error that is returned when a day is registered twice
+type ErrDuplicateSolver struct {
+	Day int
+}
+
+// Error returns the error message
+func (e ErrDuplicateSolver) Error() string {
+	return fmt.Sprintf("solver already registered for day %d", e.Day)
+}
+
+// ErrSolverNotFound is an error that is returned when a day isn't registered
+type ErrSolverNotFound struct {
+	Day int
+}
+
+// Error returns the error message
+func (e ErrSolverNotFound) Error() string {
+	return fmt.Sprintf("no solver registered for day %d", e.Day)
+}
+
+// NewRegistry creates a new Registry struct
+func NewRegistry() *Registry {
+	return &Registry{
+		solvers: make(map[int]Solver),
+	}
+}
+
+// Register adds a solver to the registry
+func (r *Registry) Register(s Solver) error {
+	day := s.Info().Day
+	if _, ok := r.solvers[day]; ok {
+		return ErrDuplicateSolver{Day: day}
+	}
+	r.solvers[day] = s
+	return nil
+}
+
+// Get returns the solver for a day
+func (r *Registry) Get(day int) (Solver, error) {
+	s, ok := r.solvers[day]
+	if !ok {
+		return nil, ErrSolverNotFound{Day: day}
+	}
+	return s, nil
+}
+
+// Solvers returns every registered solver, ordered by day
+func (r *Registry) Solvers() []Solver {
+	days := make([]int, 0, len(r.solvers))
+	for day := range r.solvers {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	solvers := make([]Solver, 0, len(days))
+	for _, day := range days {
+		solvers = append(solvers, r.solvers[day])
+	}
+	return solvers
+}
@@ -1,80 +1,179 @@
 package common
 
 import (
+	"context"
+	"crypto/sha256"
 	"embed"
-	"fmt"
+	"encoding/hex"
+	"errors"
 	"io/fs"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
 
 	"github.com/spf13/viper"
 )
 
+const (
+	// InputDirsKey is the viper key for the directories layered over the cache and embedded resources
+	InputDirsKey = "input-dirs"
+	// CacheDirKey is the viper key for the directory downloaded resources are cached in
+	CacheDirKey = "cache-dir"
+	// EmbeddedLayer is the name of the layer holding the embedded resources
+	EmbeddedLayer = "embedded"
+	// appDirName is the name of the directory used under the user's cache and config directories
+	appDirName = "aoc2024"
+)
+
 //go:embed resources/*
 var resources embed.FS
 
-// Resources is a struct that contains the resources
+// Resources is a struct that contains the resource layers, earlier layers override later ones
 type Resources struct {
-	FS        embed.FS
-	FileNames []string
-	Files     []*File
+	Layers []*Layer
+}
+
+// Layer is a struct that contains a named filesystem of resources
+type Layer struct {
+	Name string
+	FS   fs.FS
 }
 
 // File is a struct that contains the file and metadata
 type File struct {
 	Name     string
+	Layer    string
 	DirEntry fs.DirEntry
 	Contents []byte
 }
 
 // newFile creates a new File struct
-func newFile(de fs.DirEntry, contents []byte) *File {
+func newFile(de fs.DirEntry, layer string, contents []byte) *File {
 	return &File{
 		Name:     de.Name(),
+		Layer:    layer,
 		DirEntry: de,
 		Contents: contents,
 	}
 }
 
-// GetFile returns a file from the resources by name, nil if not found
+// Hash returns the sha256 of the file contents, hex encoded
+func (f *File) Hash() string {
+	sum := sha256.Sum256(f.Contents)
+	return hex.EncodeToString(sum[:])
+}
+
+// GetFile returns a file from the first layer that has it by name, nil if not found
 func (r *Resources) GetFile(h *Helpers, name string) *File {
-	h.Logger.Debug(fmt.Sprintf("Getting file: %s", name))
-	for _, f := range r.Files {
-		if f.Name == name {
-			return f
+	for _, layer := range r.Layers {
+		f, err := layer.getFile(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			h.Logger.Error("Error reading file", "file", name, "layer", layer.Name, ErrAttr, err)
+			continue
 		}
+		h.Logger.Debug("Found file", "file", name, "layer", layer.Name)
+		return f
 	}
 	return nil
 }
 
-// NewResources creates a new Resources struct
-func NewResources(l *slog.Logger, v *viper.Viper) (*Resources, error) {
-	r := &Resources{
-		FS: resources,
+// FileNames returns the names of the files across every layer
+func (r *Resources) FileNames(h *Helpers) []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, layer := range r.Layers {
+		entries, err := fs.ReadDir(layer.FS, ".")
+		if err != nil {
+			h.Logger.Debug("Error listing layer", "layer", layer.Name, ErrAttr, err)
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() || seen[e.Name()] {
+				continue
+			}
+			seen[e.Name()] = true
+			names = append(names, e.Name())
+		}
 	}
+	sort.Strings(names)
+	return names
+}
 
-	files, err := r.FS.ReadDir("resources")
+// getFile reads a file from the layer
+func (l *Layer) getFile(name string) (*File, error) {
+	info, err := fs.Stat(l.FS, name)
 	if err != nil {
-		l.Error(fmt.Sprintf("Error reading resources: %s", err))
 		return nil, err
 	}
+	if info.IsDir() {
+		return nil, fs.ErrNotExist
+	}
+	contents, err := fs.ReadFile(l.FS, name)
+	if err != nil {
+		return nil, err
+	}
+	return newFile(fs.FileInfoToDirEntry(info), l.Name, contents), nil
+}
 
-	r.Files = make([]*File, 0, len(files))
-	for _, f := range files {
-		n := f.Name()
-		l.Debug(fmt.Sprintf("file: %s", n))
-		contents, err := r.FS.ReadFile(fmt.Sprintf("resources/%s", n))
-		if err != nil {
-			l.Error(fmt.Sprintf("Error reading file: %s", err))
-			return nil, err
+// newDirLayer creates a new layer for a directory on disk
+func newDirLayer(dir string) *Layer {
+	return &Layer{
+		Name: dir,
+		FS:   os.DirFS(dir),
+	}
+}
+
+// CacheDir returns the directory downloaded resources are cached in
+func CacheDir(v *viper.Viper) (string, error) {
+	dir := v.GetString(CacheDirKey)
+	if dir != "" {
+		return dir, nil
+	}
+	userDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(userDir, appDirName), nil
+}
+
+// NewResources creates a new Resources struct, layering the input dirs, then the cache dir, then the embedded resources
+func NewResources(l *slog.Logger, v *viper.Viper) (*Resources, error) {
+	r := &Resources{}
+
+	for _, dir := range v.GetStringSlice(InputDirsKey) {
+		if dir == "" {
+			continue
 		}
-		r.Files = append(r.Files, newFile(f, contents))
+		r.Layers = append(r.Layers, newDirLayer(dir))
 	}
 
-	l.Debug("resources:")
-	for _, f := range files {
-		n := f.Name()
-		r.FileNames = append(r.FileNames, n)
-		l.Debug(fmt.Sprintf("file: %s", n))
+	cacheDir, err := CacheDir(v)
+	if err != nil {
+		l.Debug("Skipping cache layer", ErrAttr, err)
+	} else {
+		r.Layers = append(r.Layers, newDirLayer(cacheDir))
+	}
+
+	embedded, err := fs.Sub(resources, "resources")
+	if err != nil {
+		l.Error("Error reading resources", ErrAttr, err)
+		return nil, err
+	}
+	r.Layers = append(r.Layers, &Layer{
+		Name: EmbeddedLayer,
+		FS:   embedded,
+	})
+
+	if l.Enabled(context.Background(), slog.LevelDebug) {
+		names := make([]string, 0, len(r.Layers))
+		for _, layer := range r.Layers {
+			names = append(names, layer.Name)
+		}
+		l.Debug("Resource layers", "layers", names)
 	}
 
 	return r, nil
@@ -0,0 +1,70 @@
+package common_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/mrlunchbox777/2024-advent-of-code/common/test"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetFileLayers is a test for the layer order of GetFile
+func TestGetFileLayers(t *testing.T) {
+	inputDir := t.TempDir()
+	cacheDir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(inputDir, "day1-star1"), []byte("input"), 0o644))
+	assert.Nil(t, os.WriteFile(filepath.Join(cacheDir, "day1-star1"), []byte("cache"), 0o644))
+	assert.Nil(t, os.WriteFile(filepath.Join(cacheDir, "day2-star1"), []byte("cache"), 0o644))
+	testCases := []struct {
+		name          string
+		file          string
+		expectedLayer string
+		expected      string
+	}{
+		{
+			name:          "input_dir_overrides_cache",
+			file:          "day1-star1",
+			expectedLayer: inputDir,
+			expected:      "input",
+		},
+		{
+			name:          "cache_overrides_embedded",
+			file:          "day2-star1",
+			expectedLayer: cacheDir,
+			expected:      "cache",
+		},
+		{
+			name:          "embedded_fallback",
+			file:          "day3-star1",
+			expectedLayer: common.EmbeddedLayer,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			s := test.NewTestStreams()
+			l := test.NewTestSlog(s.Streams)
+			v := viper.New()
+			v.Set(common.InputDirsKey, []string{inputDir})
+			v.Set(common.CacheDirKey, cacheDir)
+			h, err := common.NewHelpers(s.Streams, v, l)
+			if err != nil {
+				l.Error(err.Error())
+				t.Log(err)
+				t.Fail()
+			}
+			// Act
+			f := h.Resources.GetFile(h, tc.file)
+			// Assert
+			assert.NotNil(t, f)
+			assert.Equal(t, tc.file, f.Name)
+			assert.Equal(t, tc.expectedLayer, f.Layer)
+			if tc.expected != "" {
+				assert.Equal(t, tc.expected, string(f.Contents))
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// OutputKey is the viper key for the output format of results
+	OutputKey = "output"
+	// TextOutput writes a line per result, e.g. Day 1 Star 1: 123
+	TextOutput = OutputFormat("text")
+	// JSONOutput writes the results as a json array
+	JSONOutput = OutputFormat("json")
+	// YAMLOutput writes the results as a yaml sequence
+	YAMLOutput = OutputFormat("yaml")
+	// TableOutput writes the results as an aligned table
+	TableOutput = OutputFormat("table")
+	// AnswerOutput writes only the answer of each result
+	AnswerOutput = OutputFormat("answer")
+)
+
+// OutputFormat is the format results are rendered in
+type OutputFormat string
+
+// OutputFormats returns every output format
+func OutputFormats() []OutputFormat {
+	return []OutputFormat{TextOutput, JSONOutput, YAMLOutput, TableOutput, AnswerOutput}
+}
+
+// Result is a struct that contains the outcome of running a star, durations are
+// nanoseconds in json and duration strings in yaml
+type Result struct {
+	Day           int           `json:"day" yaml:"day"`
+	Star          Star          `json:"star" yaml:"star"`
+	Answer        int           `json:"answer" yaml:"answer"`
+	Input         string        `json:"input,omitempty" yaml:"input,omitempty"`
+	InputHash     string        `json:"inputHash,omitempty" yaml:"inputHash,omitempty"`
+	ParseDuration time.Duration `json:"parseDuration" yaml:"parseDuration"`
+	Duration      time.Duration `json:"duration" yaml:"duration"`
+	Error         string        `json:"error,omitempty" yaml:"error,omitempty"`
+	Err           error         `json:"-" yaml:"-"`
+}
+
+// ErrUnknownOutput is an error that is returned for an output format that doesn't exist
+type ErrUnknownOutput struct {
+	Format OutputFormat
+}
+
+// Error returns the error message
+func (e ErrUnknownOutput) Error() string {
+	return fmt.Sprintf("unknown output format %q, expected one of %v", e.Format, OutputFormats())
+}
+
+// SetInput records the input a result was solved against
+func (r *Result) SetInput(f *File) {
+	r.Input = f.Name
+	r.InputHash = f.Hash()
+}
+
+// SetErr records the error a result failed with
+func (r *Result) SetErr(err error) {
+	r.Err = err
+	r.Error = ""
+	if err != nil {
+		r.Error = err.Error()
+	}
+}
+
+// GetOutputFormat returns the output format from viper, or the command's default
+func GetOutputFormat(h *Helpers, def OutputFormat) (OutputFormat, error) {
+	format := OutputFormat(h.Viper.GetString(OutputKey))
+	if format == "" {
+		return def, nil
+	}
+	for _, f := range OutputFormats() {
+		if f == format {
+			return format, nil
+		}
+	}
+	return "", ErrUnknownOutput{Format: format}
+}
+
+// WriteResults renders results to the out stream in the configured format, or the command's default
+func (h *Helpers) WriteResults(def OutputFormat, results ...*Result) error {
+	format, err := GetOutputFormat(h, def)
+	if err != nil {
+		return err
+	}
+	return RenderResults(h.Streams.Out, format, results)
+}
+
+// RenderResults renders results to a writer in a format
+func RenderResults(w io.Writer, format OutputFormat, results []*Result) error {
+	switch format {
+	case TextOutput:
+		return renderText(w, results)
+	case JSONOutput:
+		e := json.NewEncoder(w)
+		e.SetIndent("", "  ")
+		return e.Encode(results)
+	case YAMLOutput:
+		e := yaml.NewEncoder(w)
+		e.SetIndent(2)
+		err := e.Encode(results)
+		if err != nil {
+			return err
+		}
+		return e.Close()
+	case TableOutput:
+		return renderTable(w, results)
+	case AnswerOutput:
+		return renderAnswers(w, results)
+	default:
+		return ErrUnknownOutput{Format: format}
+	}
+}
+
+// renderText writes a line per result
+func renderText(w io.Writer, results []*Result) error {
+	for _, r := range results {
+		value := strconv.Itoa(r.Answer)
+		if r.Error != "" {
+			value = fmt.Sprintf("error: %s", r.Error)
+		}
+		_, err := fmt.Fprintf(w, "%s %s: %s\n", SolverInfo{Day: r.Day}.Human(), r.Star.Human(), value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderTable writes the results as an aligned table
+func renderTable(w io.Writer, results []*Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tSTAR\tANSWER\tINPUT\tPARSE\tSOLVE\tSTATUS")
+	for _, r := range results {
+		status := "ok"
+		answer := strconv.Itoa(r.Answer)
+		if r.Error != "" {
+			status = fmt.Sprintf("error: %s", r.Error)
+			answer = "-"
+		}
+		input := r.Input
+		if len(r.InputHash) >= 12 {
+			input = fmt.Sprintf("%s (%s)", input, r.InputHash[:12])
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\t%s\t%s\n", r.Day, r.Star, answer, input, r.ParseDuration, r.Duration, status)
+	}
+	return tw.Flush()
+}
+
+// renderAnswers writes only the answers, a failed result writes an empty line to keep the lines aligned
+func renderAnswers(w io.Writer, results []*Result) error {
+	for _, r := range results {
+		value := strconv.Itoa(r.Answer)
+		if r.Error != "" {
+			value = ""
+		}
+		_, err := fmt.Fprintln(w, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package common_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRenderResults is a test for the RenderResults function
+func TestRenderResults(t *testing.T) {
+	failed := &common.Result{Day: 2, Star: common.Star2}
+	failed.SetErr(fmt.Errorf("bad input"))
+	results := []*common.Result{
+		{Day: 1, Star: common.Star1, Answer: 11, Input: "day1-example1", Duration: time.Millisecond},
+		failed,
+	}
+	testCases := []struct {
+		name     string
+		format   common.OutputFormat
+		expected string
+	}{
+		{
+			name:     "text",
+			format:   common.TextOutput,
+			expected: "Day 1 Star 1: 11\nDay 2 Star 2: error: bad input\n",
+		},
+		{
+			name:     "answer",
+			format:   common.AnswerOutput,
+			expected: "11\n\n",
+		},
+		{
+			name:   "json",
+			format: common.JSONOutput,
+			expected: `[
+  {
+    "day": 1,
+    "star": 1,
+    "answer": 11,
+    "input": "day1-example1",
+    "parseDuration": 0,
+    "duration": 1000000
+  },
+  {
+    "day": 2,
+    "star": 2,
+    "answer": 0,
+    "parseDuration": 0,
+    "duration": 0,
+    "error": "bad input"
+  }
+]
+`,
+		},
+		{
+			name:   "yaml",
+			format: common.YAMLOutput,
+			expected: `- day: 1
+  star: 1
+  answer: 11
+  input: day1-example1
+  parseDuration: 0s
+  duration: 1ms
+- day: 2
+  star: 2
+  answer: 0
+  parseDuration: 0s
+  duration: 0s
+  error: bad input
+`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var out bytes.Buffer
+			// Act
+			err := common.RenderResults(&out, tc.format, results)
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
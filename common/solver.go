@@ -0,0 +1,128 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Star is a part of a day's puzzle
+type Star int
+
+const (
+	// Star1 is the first star of a day
+	Star1 Star = 1
+	// Star2 is the second star of a day
+	Star2 Star = 2
+)
+
+// Stars returns every star of a day, in order
+func Stars() []Star {
+	return []Star{Star1, Star2}
+}
+
+// Use returns the command name of the star, e.g. star1
+func (s Star) Use() string {
+	return fmt.Sprintf("star%d", s)
+}
+
+// Human returns the human name of the star, e.g. Star 1
+func (s Star) Human() string {
+	return fmt.Sprintf("Star %d", s)
+}
+
+// SolverInfo is the metadata for a day's solver
+type SolverInfo struct {
+	Day   int
+	Title string
+}
+
+// Use returns the command name of the day, e.g. day1
+func (i SolverInfo) Use() string {
+	return fmt.Sprintf("day%d", i.Day)
+}
+
+// Human returns the human name of the day, e.g. Day 1
+func (i SolverInfo) Human() string {
+	return fmt.Sprintf("Day %d", i.Day)
+}
+
+// ResourceName returns the name of the day's input resource, both stars share it
+func (i SolverInfo) ResourceName() string {
+	return fmt.Sprintf("%s-%s", i.Use(), Star1.Use())
+}
+
+// Solver is the interface implemented by each day
+type Solver interface {
+	// Info returns the metadata for the day
+	Info() SolverInfo
+	// Parse parses the input once, the result is handed to each star
+	Parse(h *Helpers, f *File) (any, error)
+	// Star1 returns the answer for the first star
+	Star1(h *Helpers, in any) (int, error)
+	// Star2 returns the answer for the second star
+	Star2(h *Helpers, in any) (int, error)
+}
+
+// Commander is implemented by a solver that adds commands to its day beyond the stars, e.g. to debug an answer
+type Commander interface {
+	// Commands returns the extra commands for the day
+	Commands(h *Helpers) []*cobra.Command
+}
+
+// Flagger is implemented by a solver with settings of its own, its flags are added to its day command so the stars and
+// extra commands all have them
+type Flagger interface {
+	// AddFlags adds the day's flags to a flag set and binds them to viper, so they must only be added once
+	AddFlags(h *Helpers, fs *pflag.FlagSet) error
+	// ConfigKeys returns the viper keys of the day's flags, so they can be set in the config file
+	ConfigKeys() []string
+	// ValidateConfig returns every problem with the day's settings
+	ValidateConfig(h *Helpers) []error
+}
+
+// ErrUnknownStar is an error that is returned when a star doesn't exist
+type ErrUnknownStar struct {
+	Star Star
+}
+
+// Error returns the error message
+func (e ErrUnknownStar) Error() string {
+	return fmt.Sprintf("unknown star: %d", e.Star)
+}
+
+// ErrWrongInputType is an error that is returned when a star is handed input it didn't parse
+type ErrWrongInputType struct {
+	Expected string
+	Actual   string
+}
+
+// Error returns the error message
+func (e ErrWrongInputType) Error() string {
+	return fmt.Sprintf("wrong input type: expected %s, got %s", e.Expected, e.Actual)
+}
+
+// Solve runs a single star of a solver against its parsed input
+func Solve(h *Helpers, s Solver, star Star, in any) (int, error) {
+	switch star {
+	case Star1:
+		return s.Star1(h, in)
+	case Star2:
+		return s.Star2(h, in)
+	default:
+		return 0, ErrUnknownStar{Star: star}
+	}
+}
+
+// InputAs asserts that parsed input is the type a star expects
+func InputAs[T any](in any) (T, error) {
+	t, ok := in.(T)
+	if !ok {
+		return t, ErrWrongInputType{
+			Expected: fmt.Sprintf("%T", t),
+			Actual:   fmt.Sprintf("%T", in),
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,45 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	// TestSession is the session cookie the stub advent of code server accepts
+	TestSession = "test-session"
+)
+
+// NewTestAOCServer creates a stub advent of code server that counts the requests it serves. It serves the day 5 input
+// and answers a submitted answer with its body in answers, every request must have the user agent and TestSession
+func NewTestAOCServer(t *testing.T, userAgent string, requests *int, answers map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		assert.Equal(t, userAgent, r.UserAgent())
+		c, err := r.Cookie("session")
+		if err != nil || c.Value != TestSession {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch {
+		case r.URL.Path == "/2024/day/5/input":
+			_, _ = w.Write([]byte("47|53\n"))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/answer"):
+			assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+			assert.Nil(t, r.ParseForm())
+			assert.NotEmpty(t, r.PostForm.Get("level"))
+			body, ok := answers[r.PostForm.Get("answer")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(body))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
@@ -0,0 +1,40 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// AssertExamples asserts every example of every solver gives its expected answers
+func AssertExamples(t *testing.T, h *common.Helpers, solvers ...common.Solver) {
+	t.Helper()
+	for _, s := range solvers {
+		examples, err := common.GetExamples(h, s)
+		assert.Nil(t, err)
+		for _, e := range examples {
+			name := s.Info().ExampleName(e.Number)
+			t.Run(name, func(t *testing.T) {
+				f, err := common.GetExample(h, s, e.Number)
+				if !assert.Nil(t, err) {
+					return
+				}
+				in, err := s.Parse(h, f)
+				if !assert.Nil(t, err) {
+					return
+				}
+				for _, star := range common.Stars() {
+					expected, ok := e.Expected(star)
+					if !ok {
+						continue
+					}
+					answer, err := common.Solve(h, s, star, in)
+					assert.Nil(t, err)
+					assert.Equal(t, expected, answer, fmt.Sprintf("%s %s", name, star.Use()))
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mrlunchbox777/2024-advent-of-code/common"
+	"github.com/spf13/viper"
+)
+
+// NewTestViper creates a new viper whose cache dir is a temp dir, so tests don't depend on the real cache
+func NewTestViper(t testing.TB) *viper.Viper {
+	v := viper.New()
+	v.Set(common.CacheDirKey, t.TempDir())
+	return v
+}
@@ -1,9 +1,7 @@
 package main
 
 import (
-	"log/slog"
-	"os"
-	"strings"
+	"errors"
 
 	"github.com/spf13/cobra"
 	pFlag "github.com/spf13/pflag"
@@ -20,47 +18,28 @@ func main() {
 func run(g common.GetStreamsFunc) {
 	flags := pFlag.NewFlagSet("2024-advent-of-code", pFlag.ExitOnError)
 
-	// setup the logger
+	// setup the streams
 	streams := g()
 
-	// configure the logger
-	var level slog.Leveler
-	logLevel := os.Getenv("LOG_LEVEL")
-	switch strings.ToLower(logLevel) {
-	case "debug":
-		level = slog.LevelDebug
-	case "info":
-		level = slog.LevelInfo
-	case "warn":
-		level = slog.LevelWarn
-	case "error":
-		level = slog.LevelError
-	default:
-		level = slog.LevelInfo
-	}
-	opts := &slog.HandlerOptions{
-		AddSource: logLevel == "debug",
-		Level:     level,
-	}
-	logger := slog.New(slog.NewJSONHandler(streams.ErrOut, opts))
-	// logger := slog.New(slog.NewTextHandler(streams.ErrOut(), nil))
-	slog.SetDefault(logger)
-
 	viperInstance := viper.New()
+	// automatically read in environment variables that match supported flags
+	// e.g. log-level is a recognized flag so the corresponding env variable is AOC2024_LOG_LEVEL
+	common.BindEnv(viperInstance)
 
-	cobra.OnInitialize(func() {
-		// automatically read in environment variables that match supported flags
-		// e.g. kubeconfig is a recognized flag so the corresponding env variable is KUBECONFIG
-		viperInstance.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
-		viperInstance.AutomaticEnv()
-	})
+	// configure the logger from env until the flags and config file are read
+	logger, logFile, err := common.NewLogger(streams.ErrOut, viperInstance)
+	cobra.CheckErr(err)
 
 	helpers, err := common.NewHelpers(streams, viperInstance, logger)
 	cobra.CheckErr(err)
-	bsCmd := cmd.NewRootCmd(helpers)
+	// the helpers own the log file from here, it's closed when the config replaces the logger or the command exits
+	cobra.CheckErr(helpers.SetLogger(logger, logFile))
+	bsCmd, err := cmd.NewRootCmd(helpers)
+	cobra.CheckErr(err)
 
 	flags.AddFlagSet(bsCmd.PersistentFlags())
 	pFlag.CommandLine = flags
 
-	cobra.CheckErr(bsCmd.Execute())
+	err = bsCmd.Execute()
+	cobra.CheckErr(errors.Join(err, helpers.Close()))
 }